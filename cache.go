@@ -0,0 +1,164 @@
+package stagehand
+
+import "container/list"
+
+// DefaultCacheCapacity is the capacity of the cache created by SceneManager.Preload when none is set
+const DefaultCacheCapacity = 4
+
+// A DisposableScene releases its prepared resources, like its images, when it's evicted from the cache
+type DisposableScene[T any] interface {
+	Scene[T]
+	Dispose()
+}
+
+// A SceneCache keeps the most recently used prepared scenes warm, so switching back to them doesn't
+// prepare them again. The capacity bounds the scenes not in use by the manager, scenes being prepared
+// or in use are never evicted
+type SceneCache[T any] struct {
+	capacity int
+	entries  map[Scene[T]]*list.Element
+	order    *list.List // Most recently used first
+	active   map[Scene[T]]bool
+	evicted  []*Loader[T] // evicted while being prepared, disposed once done
+	onEvict  func(Scene[T])
+}
+
+func NewSceneCache[T any](capacity int) *SceneCache[T] {
+	return &SceneCache[T]{
+		capacity: MaxInt(capacity, 1),
+		entries:  make(map[Scene[T]]*list.Element),
+		order:    list.New(),
+		active:   make(map[Scene[T]]bool),
+	}
+}
+
+// SetEvictionHook sets a function called with every scene evicted, after its Dispose
+func (c *SceneCache[T]) SetEvictionHook(hook func(Scene[T])) {
+	c.onEvict = hook
+}
+
+// Preload starts the preparation of the scene in a goroutine, unless it's already cached. A scene
+// whose preparation failed is prepared again
+func (c *SceneCache[T]) Preload(scene AsyncLoadable[T]) *Loader[T] {
+	if loader, ok := c.Get(scene); ok && loader.Err() == nil {
+		return loader
+	}
+	c.remove(scene)
+	c.entries[scene] = c.order.PushFront(startLoader(scene))
+	c.shrink()
+	return c.entries[scene].Value.(*Loader[T])
+}
+
+// Get returns the loader of the scene and marks it as the most recently used
+func (c *SceneCache[T]) Get(scene Scene[T]) (*Loader[T], bool) {
+	e, ok := c.entries[scene]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(e)
+	return e.Value.(*Loader[T]), true
+}
+
+// Ready reports whether the scene is cached and successfully prepared
+func (c *SceneCache[T]) Ready(scene Scene[T]) bool {
+	e, ok := c.entries[scene]
+	if !ok {
+		return false
+	}
+	loader := e.Value.(*Loader[T])
+	return loader.Done() && loader.Err() == nil
+}
+
+func (c *SceneCache[T]) Len() int {
+	return c.order.Len()
+}
+
+func (c *SceneCache[T]) Capacity() int {
+	return c.capacity
+}
+
+// Evict removes the scene from the cache and disposes it, it's ignored while the scene is in use. A
+// scene still being prepared is disposed by the manager once the preparation finishes
+func (c *SceneCache[T]) Evict(scene Scene[T]) {
+	if c.active[scene] {
+		return
+	}
+	if e, ok := c.entries[scene]; ok {
+		c.order.Remove(e)
+		delete(c.entries, scene)
+		c.dispose(e.Value.(*Loader[T]))
+	}
+}
+
+// Clear evicts every scene not in use
+func (c *SceneCache[T]) Clear() {
+	for scene := range c.entries {
+		c.Evict(scene)
+	}
+}
+
+// remove drops the scene without disposing it
+func (c *SceneCache[T]) remove(scene Scene[T]) {
+	if e, ok := c.entries[scene]; ok {
+		c.order.Remove(e)
+		delete(c.entries, scene)
+	}
+}
+
+func (c *SceneCache[T]) dispose(loader *Loader[T]) {
+	if loader.Done() {
+		if d, ok := Scene[T](loader.scene).(DisposableScene[T]); ok {
+			d.Dispose()
+		}
+		if c.onEvict != nil {
+			c.onEvict(loader.scene)
+		}
+		return
+	}
+	c.evicted = append(c.evicted, loader)
+}
+
+// update disposes the scenes evicted while being prepared and evicts the scenes that no longer fit,
+// the manager calls it on the game thread every update
+func (c *SceneCache[T]) update() {
+	pending := c.evicted[:0]
+	for _, loader := range c.evicted {
+		if loader.Done() {
+			c.dispose(loader)
+		} else {
+			pending = append(pending, loader)
+		}
+	}
+	c.evicted = pending
+	c.shrink()
+}
+
+// shrink evicts the least recently used scenes until the scenes not in use fit the capacity
+func (c *SceneCache[T]) shrink() {
+	excess := c.order.Len() - c.capacity
+	for scene := range c.active {
+		if _, ok := c.entries[scene]; ok {
+			excess--
+		}
+	}
+	for e := c.order.Back(); e != nil && excess > 0; {
+		prev := e.Prev()
+		loader := e.Value.(*Loader[T])
+		if !c.active[loader.scene] && loader.Done() {
+			c.Evict(loader.scene)
+			excess--
+		}
+		e = prev
+	}
+}
+
+// setActive pins the scenes in use by the manager, they are evicted later if needed
+func (c *SceneCache[T]) setActive(scene Scene[T], active bool) {
+	if active {
+		c.active[scene] = true
+		c.Get(scene)
+		return
+	}
+	delete(c.active, scene)
+	c.shrink()
+}
@@ -0,0 +1,137 @@
+package stagehand
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type MockCachedScene struct {
+	MockScene
+	prepared int
+	disposed bool
+	err      error
+}
+
+func (m *MockCachedScene) Prepare(report func(float64)) error {
+	m.prepared++
+	return m.err
+}
+
+func (m *MockCachedScene) Dispose() {
+	m.disposed = true
+}
+
+// preload prepares the scene and waits for it
+func preload[T any](c *SceneCache[T], scene AsyncLoadable[T]) *Loader[T] {
+	loader := c.Preload(scene)
+	<-loader.done
+	return loader
+}
+
+func TestSceneCache_Preload(t *testing.T) {
+	cache := NewSceneCache[int](2)
+	scene := &MockCachedScene{}
+
+	loader := preload[int](cache, scene)
+	assert.True(t, cache.Ready(scene))
+	assert.Equal(t, 1, cache.Len())
+
+	// Cached scenes are not prepared again
+	assert.Equal(t, loader, cache.Preload(scene))
+	assert.Equal(t, 1, scene.prepared)
+}
+
+func TestSceneCache_PreloadFailed(t *testing.T) {
+	cache := NewSceneCache[int](2)
+	scene := &MockCachedScene{err: errors.New("missing asset")}
+
+	preload[int](cache, scene)
+	assert.False(t, cache.Ready(scene))
+
+	scene.err = nil
+	preload[int](cache, scene)
+	assert.True(t, cache.Ready(scene))
+	assert.Equal(t, 2, scene.prepared)
+}
+
+func TestSceneCache_Eviction(t *testing.T) {
+	cache := NewSceneCache[int](2)
+	var evicted []Scene[int]
+	cache.SetEvictionHook(func(s Scene[int]) { evicted = append(evicted, s) })
+
+	a, b, c := &MockCachedScene{}, &MockCachedScene{}, &MockCachedScene{}
+	preload[int](cache, a)
+	preload[int](cache, b)
+	cache.Get(a)
+
+	// The least recently used scene is evicted
+	preload[int](cache, c)
+	assert.Equal(t, 2, cache.Len())
+	assert.True(t, b.disposed)
+	assert.Equal(t, []Scene[int]{b}, evicted)
+	assert.True(t, cache.Ready(a))
+	assert.True(t, cache.Ready(c))
+
+	cache.Clear()
+	assert.Equal(t, 0, cache.Len())
+	assert.True(t, a.disposed)
+	assert.True(t, c.disposed)
+}
+
+func TestSceneCache_EvictWhilePreparing(t *testing.T) {
+	cache := NewSceneCache[int](1)
+	scene := NewMockAsyncScene()
+	var evicted []Scene[int]
+	cache.SetEvictionHook(func(s Scene[int]) { evicted = append(evicted, s) })
+
+	loader := cache.Preload(scene)
+	cache.Evict(scene)
+	assert.Equal(t, 0, cache.Len())
+	assert.Empty(t, evicted)
+
+	// The scene is disposed once the preparation finishes
+	scene.release <- nil
+	<-loader.done
+	cache.update()
+	assert.Equal(t, []Scene[int]{scene}, evicted)
+}
+
+func TestSceneManager_PreloadKeepsActiveScenes(t *testing.T) {
+	current := &MockCachedScene{}
+	sm := NewSceneManager[int](&MockScene{}, 0)
+	sm.SetCache(NewSceneCache[int](1))
+	preload[int](sm.Cache(), current)
+	sm.SwitchTo(current)
+
+	// Preloading the next scene doesn't evict the one in use
+	next := &MockCachedScene{}
+	<-sm.Preload(next).done
+	sm.Update()
+	assert.Equal(t, 2, sm.Cache().Len())
+	assert.False(t, current.disposed)
+
+	// Once left, it's kept warm until it no longer fits
+	sm.SwitchWithLoader(next, &MockScene{}, nil)
+	assert.Equal(t, next, sm.current)
+	assert.Equal(t, 1, next.prepared)
+	assert.False(t, current.disposed)
+
+	preload[int](sm.Cache(), &MockCachedScene{})
+	assert.True(t, current.disposed)
+	assert.False(t, next.disposed)
+}
+
+func TestSceneManager_Preload(t *testing.T) {
+	sm := NewSceneManager[int](&MockScene{}, 0)
+	scene := &MockCachedScene{}
+	loader := sm.Preload(scene)
+	assert.Equal(t, DefaultCacheCapacity, sm.Cache().Capacity())
+
+	<-loader.done
+	trans := &baseTransitionImplementation{}
+	sm.SwitchWithLoader(scene, &MockScene{}, trans)
+	assert.Equal(t, trans, sm.current)
+	assert.True(t, scene.loadCalled)
+}
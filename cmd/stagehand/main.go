@@ -0,0 +1,149 @@
+// Command stagehand lints and visualizes the rule sets of SceneDirector, written in JSON or YAML,
+// without launching the game.
+//
+// Usage:
+//
+//	stagehand validate [-transitions names] file
+//	stagehand graph [-format dot|mermaid|text] [-transitions names] file
+//	stagehand paths -from scene -to scene [-transitions names] file
+//
+// Every scene ID in the rule set is accepted, and trigger and guard names are defined as they are
+// found. Custom transition types must be listed with -transitions, the parameters of the transitions
+// are checked when the game loads the rule set. The command doesn't depend on Ebitengine, so it runs
+// without a display, e.g. in CI.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/joelschutz/stagehand/ruleset"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+const usage = `usage:
+  stagehand validate [-transitions names] file
+  stagehand graph [-format dot|mermaid|text] [-transitions names] file
+  stagehand paths -from scene -to scene [-transitions names] file
+`
+
+// run runs the command and returns the exit code: 1 if the rule set has issues or can't be loaded and
+// 2 for usage errors
+func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 || (args[0] != "validate" && args[0] != "graph" && args[0] != "paths") {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+	fs := flag.NewFlagSet("stagehand "+args[0], flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	transitions := fs.String("transitions", "", "comma-separated custom transition types")
+	format := fs.String("format", "text", "graph format: dot, mermaid or text")
+	from := fs.String("from", "", "scene the path starts at")
+	to := fs.String("to", "", "scene the path ends at")
+	if err := fs.Parse(args[1:]); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 || (args[0] == "paths" && (*from == "" || *to == "")) {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+
+	graph, err := load(fs.Arg(0), strings.Split(*transitions, ","))
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	switch args[0] {
+	case "validate":
+		issues := graph.Validate()
+		for _, issue := range issues {
+			fmt.Fprintln(stdout, issue)
+		}
+		if len(issues) > 0 {
+			return 1
+		}
+	case "graph":
+		write := map[string]func(io.Writer) error{
+			"dot":     graph.WriteDOT,
+			"mermaid": graph.WriteMermaid,
+			"text":    graph.WriteText,
+		}[*format]
+		if write == nil {
+			fmt.Fprintf(stderr, "unknown format %q\n", *format)
+			return 2
+		}
+		if err := write(stdout); err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+	case "paths":
+		path, err := graph.ShortestPath(*from, *to)
+		if err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+		for _, step := range path {
+			label := graph.Triggers.NameOf(step.Trigger)
+			if step.Guarded {
+				label += " [guarded]"
+			}
+			if step.Transition != "" {
+				label += " / " + step.Transition
+			}
+			fmt.Fprintf(stdout, "%s -> %s: %s\n", step.From, step.To, label)
+		}
+	}
+	return 0
+}
+
+// load builds the graph of the rule set in the file, with its own registry for the trigger names
+func load(path string, transitions []string) (*ruleset.Graph, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var config ruleset.Config
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		config, err = ruleset.ParseJSON(data)
+	case ".yaml", ".yml":
+		config, err = ruleset.ParseYAML(data)
+	default:
+		err = errors.New("the rule set must be a .json, .yaml or .yml file")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[string]bool)
+	for _, names := range [][]string{ruleset.TransitionTypes, transitions} {
+		for _, name := range names {
+			if name != "" {
+				known[ruleset.NormalizeName(name)] = true
+			}
+		}
+	}
+	triggers := ruleset.NewTriggerRegistry()
+	for _, c := range config.Directives() {
+		if _, err := strconv.Atoi(c.Trigger); err != nil {
+			triggers.Define(c.Trigger)
+		}
+		if c.Transition != nil {
+			name, _ := c.Transition["type"].(string)
+			if !known[ruleset.NormalizeName(name)] {
+				return nil, fmt.Errorf("%w: %q", ruleset.ErrUnknownTransition, name)
+			}
+		}
+	}
+	return ruleset.NewGraph(config, triggers)
+}
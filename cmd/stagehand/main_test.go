@@ -0,0 +1,117 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const ruleSet = `
+start: menu
+scenes:
+  menu:
+    - {trigger: play, dest: level, transition: {type: fade, duration: 500ms}}
+    - {trigger: options, dest: settings, guard: "!inGame"}
+  level:
+    - {trigger: win, dest: credits, transition: {type: confetti}}
+  settings:
+    - {trigger: back, dest: menu}
+`
+
+func writeRuleSet(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func runCommand(args ...string) (int, string, string) {
+	var stdout, stderr strings.Builder
+	code := run(args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestValidate(t *testing.T) {
+	path := writeRuleSet(t, "flow.yaml", ruleSet)
+
+	code, _, stderr := runCommand("validate", path)
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, `unknown transition type: "confetti"`)
+
+	code, stdout, _ := runCommand("validate", "-transitions", "confetti", path)
+	assert.Equal(t, 1, code)
+	assert.Equal(t, "credits: dead end: no directive leaves this scene\n", stdout)
+
+	clean := writeRuleSet(t, "clean.json", `{"start": "menu", "scenes": {"menu": [{"trigger": "loop", "dest": "menu"}]}}`)
+	code, stdout, _ = runCommand("validate", clean)
+	assert.Equal(t, 0, code)
+	assert.Empty(t, stdout)
+}
+
+func TestGraph(t *testing.T) {
+	path := writeRuleSet(t, "flow.yml", ruleSet)
+
+	code, stdout, _ := runCommand("graph", "-transitions", "confetti", path)
+	assert.Equal(t, 0, code)
+	assert.Contains(t, stdout, "menu (start)\n  play / fade -> level\n  options [guarded] -> settings\n")
+
+	code, stdout, _ = runCommand("graph", "-format", "dot", "-transitions", "confetti", path)
+	assert.Equal(t, 0, code)
+	assert.True(t, strings.HasPrefix(stdout, "digraph stagehand {"))
+
+	code, stdout, _ = runCommand("graph", "-format", "mermaid", "-transitions", "confetti", path)
+	assert.Equal(t, 0, code)
+	assert.True(t, strings.HasPrefix(stdout, "stateDiagram-v2"))
+
+	code, _, _ = runCommand("graph", "-format", "svg", "-transitions", "confetti", path)
+	assert.Equal(t, 2, code)
+}
+
+func TestPaths(t *testing.T) {
+	path := writeRuleSet(t, "flow.yaml", ruleSet)
+
+	code, stdout, _ := runCommand("paths", "-from", "settings", "-to", "credits", "-transitions", "confetti", path)
+	assert.Equal(t, 0, code)
+	assert.Equal(t, "settings -> menu: back\nmenu -> level: play / fade\nlevel -> credits: win / confetti\n", stdout)
+
+	code, _, stderr := runCommand("paths", "-from", "credits", "-to", "menu", "-transitions", "confetti", path)
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "no path")
+}
+
+func TestUsage(t *testing.T) {
+	code, _, _ := runCommand()
+	assert.Equal(t, 2, code)
+	code, _, _ = runCommand("lint", "flow.yaml")
+	assert.Equal(t, 2, code)
+	code, _, _ = runCommand("paths", "flow.yaml")
+	assert.Equal(t, 2, code)
+}
+
+func TestCommand_WithoutDisplay(t *testing.T) {
+	gotool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go tool not found")
+	}
+
+	deps, err := exec.Command(gotool, "list", "-deps", ".").Output()
+	assert.NoError(t, err)
+	assert.NotContains(t, string(deps), "github.com/hajimehoshi/ebiten")
+
+	bin := filepath.Join(t.TempDir(), "stagehand")
+	out, err := exec.Command(gotool, "build", "-o", bin, ".").CombinedOutput()
+	assert.NoError(t, err, string(out))
+
+	cmd := exec.Command(bin, "graph", "-transitions", "confetti", writeRuleSet(t, "flow.yaml", ruleSet))
+	for _, env := range os.Environ() {
+		if !strings.HasPrefix(env, "DISPLAY=") && !strings.HasPrefix(env, "WAYLAND_DISPLAY=") {
+			cmd.Env = append(cmd.Env, env)
+		}
+	}
+	out, err = cmd.Output()
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(out), "credits\nlevel\n"))
+}
@@ -0,0 +1,268 @@
+package stagehand
+
+import ebiten "github.com/hajimehoshi/ebiten/v2"
+
+// A subTransition is a transition run by a combinator. It's the controller of the transition, so
+// ending it reports its completion instead of finishing the switch
+type subTransition[T any] struct {
+	SceneTransition[T]
+	done    bool
+	running bool
+}
+
+func (s *subTransition[T]) start(fromScene, toScene Scene[T]) {
+	s.done, s.running = false, true
+	s.SceneTransition.Start(sharedScene[T]{fromScene}, sharedScene[T]{toScene}, s)
+}
+
+// end ends the transition if it's still running, so it releases its resources
+func (s *subTransition[T]) end() {
+	if s.running {
+		s.SceneTransition.End()
+	}
+}
+
+func (s *subTransition[T]) ReturnFromTransition(scene, origin Scene[T]) {
+	s.done, s.running = true, false
+}
+
+func newSubTransitions[T any](transitions []SceneTransition[T]) []*subTransition[T] {
+	subs := make([]*subTransition[T], len(transitions))
+	for i, trans := range transitions {
+		subs[i] = &subTransition[T]{SceneTransition: trans}
+	}
+	return subs
+}
+
+// A sharedScene is a scene seen by a sub-transition, the combinator updates the scenes once per frame
+// instead of the sub-transitions
+type sharedScene[T any] struct {
+	Scene[T]
+}
+
+func (s sharedScene[T]) Update() error {
+	return nil
+}
+
+func (s sharedScene[T]) unwrap() Scene[T] {
+	return s.Scene
+}
+
+// unwrapScene returns the scene behind a scene wrapped by stagehand, like the scenes shared with
+// sub-transitions
+func unwrapScene[T any](scene Scene[T]) Scene[T] {
+	for {
+		s, ok := scene.(interface{ unwrap() Scene[T] })
+		if !ok {
+			return scene
+		}
+		scene = s.unwrap()
+	}
+}
+
+// A SequenceTransition runs its transitions one after the other between the same scenes
+type SequenceTransition[T any] struct {
+	BaseTransition[T]
+	transitions []*subTransition[T]
+	current     int
+}
+
+// Sequence returns a transition that runs the transitions in order, the switch finishes when the
+// last one completes
+func Sequence[T any](transitions ...SceneTransition[T]) *SequenceTransition[T] {
+	return &SequenceTransition[T]{transitions: newSubTransitions(transitions)}
+}
+
+// Start starts the first transition
+func (t *SequenceTransition[T]) Start(fromScene, toScene Scene[T], sm SceneController[T]) {
+	t.BaseTransition.Start(fromScene, toScene, sm)
+	t.current = 0
+	if len(t.transitions) > 0 {
+		t.transitions[0].start(fromScene, toScene)
+	}
+}
+
+// End ends the running transition and the sequence
+func (t *SequenceTransition[T]) End() {
+	for _, sub := range t.transitions {
+		sub.end()
+	}
+	t.BaseTransition.End()
+}
+
+// Update updates the running transition and starts the next one once it completes
+func (t *SequenceTransition[T]) Update() error {
+	if t.current < len(t.transitions) {
+		sub := t.transitions[t.current]
+		if err := sub.Update(); err != nil {
+			return err
+		}
+		if sub.done {
+			t.current++
+			if t.current < len(t.transitions) {
+				t.transitions[t.current].start(t.fromScene, t.toScene)
+			}
+		}
+	}
+	if t.current >= len(t.transitions) {
+		t.End()
+	}
+
+	return t.UpdateScenes()
+}
+
+// Draw draws the running transition
+func (t *SequenceTransition[T]) Draw(screen *ebiten.Image) {
+	if t.current < len(t.transitions) {
+		t.transitions[t.current].Draw(screen)
+		return
+	}
+	t.toScene.Draw(screen)
+}
+
+// A ParallelTransition runs its transitions at the same time, each one applied to the output of the
+// previous ones
+type ParallelTransition[T any] struct {
+	BaseTransition[T]
+	transitions []*subTransition[T]
+}
+
+// Parallel returns a transition that runs the transitions at once, the switch finishes when all of
+// them complete. The first transition goes from the origin scene, and every later one goes from the
+// frames drawn by the one before it, so the effects are composed instead of drawn over each other
+func Parallel[T any](transitions ...SceneTransition[T]) *ParallelTransition[T] {
+	return &ParallelTransition[T]{transitions: newSubTransitions(transitions)}
+}
+
+// Start starts all the transitions
+func (t *ParallelTransition[T]) Start(fromScene, toScene Scene[T], sm SceneController[T]) {
+	t.BaseTransition.Start(fromScene, toScene, sm)
+	for i, sub := range t.transitions {
+		if i == 0 {
+			sub.start(fromScene, toScene)
+			continue
+		}
+		sub.start(parallelStage[T]{Scene: fromScene, parallel: t, index: i - 1}, toScene)
+	}
+}
+
+// End ends the running transitions and the parallel transition
+func (t *ParallelTransition[T]) End() {
+	for _, sub := range t.transitions {
+		sub.end()
+	}
+	t.BaseTransition.End()
+}
+
+// Update updates the transitions that are still running
+func (t *ParallelTransition[T]) Update() error {
+	done := true
+	for _, sub := range t.transitions {
+		if sub.done {
+			continue
+		}
+		if err := sub.Update(); err != nil {
+			return err
+		}
+		done = done && sub.done
+	}
+	if done {
+		t.End()
+	}
+
+	return t.UpdateScenes()
+}
+
+// Draw draws the last transition, which draws the earlier ones as its origin
+func (t *ParallelTransition[T]) Draw(screen *ebiten.Image) {
+	t.drawStage(screen, len(t.transitions)-1)
+}
+
+// drawStage draws the output of the transitions up to the index, a finished transition is replaced by
+// the destination scene
+func (t *ParallelTransition[T]) drawStage(screen *ebiten.Image, index int) {
+	switch {
+	case index < 0:
+		t.fromScene.Draw(screen)
+	case t.transitions[index].done:
+		t.toScene.Draw(screen)
+	default:
+		t.transitions[index].Draw(screen)
+	}
+}
+
+// A parallelStage is the origin of a transition run by Parallel, it draws the output of the previous
+// transitions in place of the origin scene
+type parallelStage[T any] struct {
+	Scene[T]
+	parallel *ParallelTransition[T]
+	index    int
+}
+
+func (s parallelStage[T]) Draw(screen *ebiten.Image) {
+	s.parallel.drawStage(screen, s.index)
+}
+
+func (s parallelStage[T]) unwrap() Scene[T] {
+	return s.Scene
+}
+
+// A ReverseTransition plays a transition backwards
+type ReverseTransition[T any] struct {
+	BaseTransition[T]
+	transition *subTransition[T]
+	timeline   *Timeline // timeline of the transition, reversed while it runs
+	reversed   bool      // direction of the timeline before it was reversed
+}
+
+// Reverse returns a transition that plays the transition backwards, from the destination to the
+// origin. The transition must be driven by a Timeline, otherwise only the scenes are swapped. The
+// timeline is only reversed while the returned transition runs, so the transition can still be used on
+// its own
+func Reverse[T any](transition SceneTransition[T]) *ReverseTransition[T] {
+	return &ReverseTransition[T]{transition: &subTransition[T]{SceneTransition: transition}}
+}
+
+// Start starts the transition with the scenes swapped
+func (t *ReverseTransition[T]) Start(fromScene, toScene Scene[T], sm SceneController[T]) {
+	t.BaseTransition.Start(fromScene, toScene, sm)
+	t.restore()
+	if tr, ok := t.transition.SceneTransition.(interface{ Timeline() *Timeline }); ok && tr.Timeline() != nil {
+		t.timeline, t.reversed = tr.Timeline(), tr.Timeline().Reversed()
+		t.timeline.SetReversed(!t.reversed)
+	}
+	t.transition.start(toScene, fromScene)
+}
+
+// End ends the reversed transition if it's running, restores the direction of its timeline and ends
+// the transition
+func (t *ReverseTransition[T]) End() {
+	t.transition.end()
+	t.restore()
+	t.BaseTransition.End()
+}
+
+// restore sets the timeline of the transition back to its direction
+func (t *ReverseTransition[T]) restore() {
+	if t.timeline != nil {
+		t.timeline.SetReversed(t.reversed)
+		t.timeline = nil
+	}
+}
+
+// Update updates the transition and ends once it completes
+func (t *ReverseTransition[T]) Update() error {
+	if err := t.transition.Update(); err != nil {
+		return err
+	}
+	if t.transition.done {
+		t.End()
+	}
+
+	return t.UpdateScenes()
+}
+
+// Draw draws the transition
+func (t *ReverseTransition[T]) Draw(screen *ebiten.Image) {
+	t.transition.Draw(screen)
+}
@@ -0,0 +1,209 @@
+package stagehand
+
+import (
+	"testing"
+
+	ebiten "github.com/hajimehoshi/ebiten/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+// countingScene counts its updates and records the states it's loaded with
+type countingScene struct {
+	MockScene
+	updates int
+	loads   []int
+}
+
+func (m *countingScene) Load(state int, sm SceneController[int]) {
+	m.loads = append(m.loads, state)
+	m.MockScene.Load(state, sm)
+}
+
+func (m *countingScene) Update() error {
+	m.updates++
+	return m.MockScene.Update()
+}
+
+// runFrame returns a function that updates and draws the manager like the game loop
+func runFrame[T any](sm *SceneManager[T]) func() {
+	screen := ebiten.NewImage(100, 100)
+	return func() {
+		sm.Update()
+		sm.Draw(screen)
+	}
+}
+
+func TestSequence(t *testing.T) {
+	from := &countingScene{}
+	to := &countingScene{}
+	first := NewFadeTransition[int](1)
+	second := NewSlideTransition[int](LeftToRight, .5)
+	trans := Sequence[int](first, second)
+	sm := NewSceneManager[int](from, 0)
+	sm.SwitchWithTransition(to, trans)
+	frame := runFrame[int](sm)
+
+	frame()
+	frame()
+	assert.Equal(t, 1, trans.current)
+	assert.Equal(t, 0., second.offset)
+
+	frame()
+	assert.Equal(t, .5, second.offset)
+	assert.Equal(t, trans, sm.current)
+
+	frame()
+	assert.Equal(t, to, sm.current)
+
+	// The scenes are updated once per frame
+	assert.Equal(t, 4, from.updates)
+	assert.Equal(t, 4, to.updates)
+}
+
+func TestSequence_Empty(t *testing.T) {
+	from := &MockScene{}
+	to := &MockScene{}
+	sm := NewSceneManager[int](from, 0)
+	sm.SwitchWithTransition(to, Sequence[int]())
+
+	sm.Update()
+	assert.Equal(t, to, sm.current)
+}
+
+// drawnTransition draws its origin scene and counts its draws
+type drawnTransition struct {
+	BaseTransition[int]
+	draws int
+}
+
+func newDrawnTransition(ticks int) *drawnTransition {
+	t := &drawnTransition{}
+	t.timeline = NewTicksTimeline(ticks)
+	return t
+}
+
+func (t *drawnTransition) Draw(screen *ebiten.Image) {
+	t.draws++
+	t.fromScene.Draw(screen)
+}
+
+func TestParallel(t *testing.T) {
+	from := &countingScene{}
+	to := &countingScene{}
+	fast := newDrawnTransition(2)
+	slow := newDrawnTransition(4)
+	trans := Parallel[int](fast, slow)
+	sm := NewSceneManager[int](from, 0)
+	sm.SwitchWithTransition(to, trans)
+	frame := runFrame[int](sm)
+
+	// The slow transition goes from the frames drawn by the fast one
+	frame()
+	assert.Equal(t, 1, fast.draws)
+	assert.Equal(t, 1, slow.draws)
+	assert.True(t, from.drawCalled)
+	assert.False(t, to.drawCalled)
+
+	// The finished transition is replaced by the destination scene
+	frame()
+	assert.True(t, trans.transitions[0].done)
+	assert.False(t, trans.transitions[1].done)
+	assert.Equal(t, 1, fast.draws)
+	assert.Equal(t, 2, slow.draws)
+	assert.True(t, to.drawCalled)
+	assert.Equal(t, trans, sm.current)
+
+	frame()
+	frame()
+	assert.Equal(t, to, sm.current)
+	assert.Equal(t, 4, from.updates)
+	assert.Equal(t, 4, to.updates)
+}
+
+func TestParallel_ComposedScene(t *testing.T) {
+	from := &MockFocalScene{}
+	to := &MockScene{}
+	slide := NewSlideTransition[int](LeftToRight, .5)
+	iris := NewIrisTransition[int](CircleShape, IrisOpen, NewTicksTimeline(2))
+	trans := Parallel[int](slide, iris)
+	trans.Start(from, to, NewSceneManager[int](from, 0))
+
+	// The later transitions still see the origin scene
+	assert.Equal(t, from, unwrapScene(iris.fromScene))
+	x, y := iris.FocalPoint(100, 100)
+	assert.Equal(t, 10., x)
+	assert.Equal(t, 20., y)
+}
+
+func TestCombinators_End(t *testing.T) {
+	for name, combine := range map[string]func(...SceneTransition[int]) SceneTransition[int]{
+		"sequence": func(ts ...SceneTransition[int]) SceneTransition[int] { return Sequence[int](ts...) },
+		"parallel": func(ts ...SceneTransition[int]) SceneTransition[int] { return Parallel[int](ts...) },
+		"reverse":  func(ts ...SceneTransition[int]) SceneTransition[int] { return Reverse[int](ts[0]) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			from := &MockScene{}
+			to := &MockScene{}
+			first := NewFadeTransition[int](.25)
+			second := NewSlideTransition[int](LeftToRight, .25)
+			trans := combine(first, second)
+			sm := NewSceneManager[int](from, 0)
+			sm.SwitchWithTransition(to, trans)
+			frame := runFrame[int](sm)
+			frame()
+
+			// Interrupting the combinator ends the running transitions, releasing their images
+			trans.End()
+			assert.Equal(t, to, sm.current)
+			assert.Nil(t, first.fromImg.img)
+			assert.Nil(t, first.toImg.img)
+			assert.Nil(t, second.fromImg.img)
+			assert.Nil(t, second.toImg.img)
+		})
+	}
+}
+
+func TestReverse(t *testing.T) {
+	from := &MockScene{}
+	to := &MockScene{}
+	inner := NewSlideTransition[int](LeftToRight, .25)
+	trans := Reverse[int](inner)
+	assert.False(t, inner.Timeline().Reversed())
+
+	sm := NewSceneManager[int](from, 0)
+	sm.SwitchWithTransition(to, trans)
+	assert.True(t, inner.Timeline().Reversed())
+
+	// The scenes are swapped and the progress goes backwards
+	assert.Equal(t, to, unwrapScene(inner.fromScene))
+	assert.Equal(t, from, unwrapScene(inner.toScene))
+	assert.Equal(t, 1., inner.Progress())
+
+	frame := runFrame[int](sm)
+	frame()
+	assert.Equal(t, .75, inner.offset)
+
+	for i := 0; i < 3; i++ {
+		frame()
+	}
+	assert.Equal(t, 0., inner.offset)
+	assert.Equal(t, to, sm.current)
+
+	// The transition plays forwards on its own
+	assert.False(t, inner.Timeline().Reversed())
+	sm.SwitchWithTransition(from, inner)
+	frame()
+	assert.Equal(t, .25, inner.offset)
+}
+
+func TestReverse_FocalScene(t *testing.T) {
+	from := &MockScene{}
+	to := &MockFocalScene{}
+	inner := NewIrisTransition[int](CircleShape, IrisOpen, NewTicksTimeline(2))
+	trans := Reverse[int](inner)
+	trans.Start(from, to, NewSceneManager[int](from, 0))
+
+	x, y := inner.FocalPoint(100, 100)
+	assert.Equal(t, 10., x)
+	assert.Equal(t, 20., y)
+}
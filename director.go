@@ -1,65 +1,280 @@
 package stagehand
 
-type SceneTransitionTrigger int
+import (
+	"errors"
+	"sort"
+)
+
+var (
+	ErrTriggerQueued   = errors.New("stagehand: trigger queued until the transition finishes")
+	ErrTriggerHeldBack = errors.New("stagehand: trigger held back by the interrupt policy")
+)
 
 // A Directive is a struct that represents how a scene should be transitioned
 type Directive[T any] struct {
 	Dest       Scene[T]
+	DestID     SceneID // Resolved with the registry of the director when Dest is nil
 	Transition SceneTransition[T]
 	Trigger    SceneTransitionTrigger
+	Guard      func(state T) bool // The directive only fires if it returns true for the current state
+	Priority   int                // Directives with a higher priority are checked first
+	Except     []string           // Groups of the scenes the directive doesn't apply from
 }
 
 // A SceneDirector is a struct that manages the transitions between scenes
 type SceneDirector[T any] struct {
 	SceneManager[T]
-	RuleSet map[Scene[T]][]Directive[T]
+	RuleSet       map[Scene[T]][]Directive[T]
+	IDRuleSet     map[SceneID][]Directive[T] // Rules of the scenes built by the registry, after the RuleSet ones
+	GroupRuleSet  map[string][]Directive[T]  // Rules of the scenes in each group, after the scene ones
+	GlobalRuleSet []Directive[T]             // Rules of every scene, after the group ones
+	Groups        map[Scene[T]][]string      // Groups of each scene
+	IDGroups      map[SceneID][]string       // Groups of the scenes built by the registry
+	reducer       func(state T, payload any) T
+	start         Scene[T]
+	parent        *SceneDirector[T] // director the director is loaded in as a scene, if any
+	triggers      *TriggerRegistry  // names the triggers, Triggers if nil
 }
 
 func NewSceneDirector[T any](scene Scene[T], state T, RuleSet map[Scene[T]][]Directive[T]) *SceneDirector[T] {
-	s := &SceneDirector[T]{RuleSet: RuleSet}
+	s := &SceneDirector[T]{RuleSet: RuleSet, start: scene}
+	s.owner = s
 	s.current = scene
-	scene.Load(state, s)
+	s.load(scene, state, s)
 	return s
 }
 
-// ProcessTrigger finds if a transition should be triggered
-func (d *SceneDirector[T]) ProcessTrigger(trigger SceneTransitionTrigger) {
-	if prevTransition, ok := d.current.(SceneTransition[T]); ok {
-		// previous transition is still running, end it to process trigger
-		prevTransition.End()
-	}
-
-	for _, directive := range d.RuleSet[d.current.(Scene[T])] {
-		if directive.Trigger == trigger {
-			if directive.Transition != nil {
-				// With transition
-				// Equivalent to SwitchWithTransition
-				sc := d.current.(Scene[T])
-				directive.Transition.Start(sc, directive.Dest, d)
-				if c, ok := sc.(TransitionAwareScene[T]); ok {
-					directive.Dest.Load(c.PreTransition(directive.Dest), d)
-				} else {
-					directive.Dest.Load(sc.Unload(), d)
-				}
-				d.current = directive.Transition
-			} else {
-				// No transition
-				// Equivalent to SwitchTo
-				if c, ok := d.current.(Scene[T]); ok {
-					directive.Dest.Load(c.Unload(), d)
-					d.current = directive.Dest
-				}
+// NewNestedSceneDirector returns a director to be used as a scene of another director. Its start scene
+// is only loaded once the parent enters it, with the state of the previous scene
+func NewNestedSceneDirector[T any](scene Scene[T], RuleSet map[Scene[T]][]Directive[T]) *SceneDirector[T] {
+	s := &SceneDirector[T]{RuleSet: RuleSet, start: scene}
+	s.owner = s
+	return s
+}
+
+// NewSceneDirectorWithRegistry returns a director that starts at the scene registered with the ID and
+// follows rules keyed by scene IDs
+func NewSceneDirectorWithRegistry[T any](registry *Registry[T], id SceneID, state T, IDRuleSet map[SceneID][]Directive[T]) (*SceneDirector[T], error) {
+	scene, err := registry.Resolve(id)
+	if err != nil {
+		return nil, err
+	}
+	s := NewSceneDirector[T](scene, state, nil)
+	s.IDRuleSet = IDRuleSet
+	s.registry = registry
+	return s, nil
+}
+
+// Tag adds the scene to the groups
+func (d *SceneDirector[T]) Tag(scene Scene[T], groups ...string) {
+	if d.Groups == nil {
+		d.Groups = make(map[Scene[T]][]string)
+	}
+	d.Groups[scene] = append(d.Groups[scene], groups...)
+}
+
+// TagID adds the scenes built by the registry for the ID to the groups
+func (d *SceneDirector[T]) TagID(id SceneID, groups ...string) {
+	if d.IDGroups == nil {
+		d.IDGroups = make(map[SceneID][]string)
+	}
+	d.IDGroups[id] = append(d.IDGroups[id], groups...)
+}
+
+// A sceneRef refers to a scene by pointer, by ID or both, so the rules of registered scenes can be
+// found before they are built
+type sceneRef[T any] struct {
+	scene Scene[T]
+	id    SceneID // empty if the scene was not built by the registry
+}
+
+// ref returns the reference of the scene, with its ID if it was built by the registry
+func (d *SceneDirector[T]) ref(scene Scene[T]) sceneRef[T] {
+	ref := sceneRef[T]{scene: scene}
+	if d.registry != nil {
+		ref.id, _ = d.registry.IDOf(scene)
+	}
+	return ref
+}
+
+// groups returns the groups of the scene, a scene built by the registry is also in the group named
+// after its ID
+func (d *SceneDirector[T]) groups(scene Scene[T]) []string {
+	return d.refGroups(d.ref(scene))
+}
+
+func (d *SceneDirector[T]) refGroups(ref sceneRef[T]) []string {
+	groups := d.Groups[ref.scene]
+	if ref.id != "" {
+		groups = append(groups[:len(groups):len(groups)], d.IDGroups[ref.id]...)
+		groups = append(groups, string(ref.id))
+	}
+	return groups
+}
+
+// directives returns the rules of the scene sorted by priority. Directives of the same priority keep
+// the order of the RuleSet, the IDRuleSet, the GroupRuleSet and the GlobalRuleSet
+func (d *SceneDirector[T]) directives(scene Scene[T]) []*Directive[T] {
+	return d.refDirectives(d.ref(scene))
+}
+
+func (d *SceneDirector[T]) refDirectives(ref sceneRef[T]) []*Directive[T] {
+	groups := d.refGroups(ref)
+	var directives []*Directive[T]
+	add := func(rules []Directive[T]) {
+		for i := range rules {
+			if !excluded(rules[i], groups) {
+				directives = append(directives, &rules[i])
 			}
+		}
+	}
+
+	add(d.RuleSet[ref.scene])
+	if ref.id != "" {
+		add(d.IDRuleSet[ref.id])
+	}
+	seen := make(map[string]bool, len(groups))
+	for _, group := range groups {
+		if !seen[group] {
+			seen[group] = true
+			add(d.GroupRuleSet[group])
+		}
+	}
+	add(d.GlobalRuleSet)
+
+	sort.SliceStable(directives, func(i, j int) bool {
+		return directives[i].Priority > directives[j].Priority
+	})
+	return directives
+}
 
+// excluded reports whether any of the groups is excepted by the directive
+func excluded[T any](directive Directive[T], groups []string) bool {
+	for _, except := range directive.Except {
+		for _, group := range groups {
+			if except == group {
+				return true
+			}
 		}
 	}
+	return false
 }
 
-func (d *SceneDirector[T]) ReturnFromTransition(scene, origin Scene[T]) {
-	if c, ok := scene.(TransitionAwareScene[T]); ok {
-		c.PostTransition(origin.Unload(), origin)
-	} else {
-		scene.Load(origin.Unload(), d)
+// destination returns the scene the directive switches to
+func (d *SceneDirector[T]) destination(directive Directive[T]) (Scene[T], error) {
+	if directive.Dest != nil {
+		return directive.Dest, nil
 	}
-	d.current = scene
+	return d.resolve(directive.DestID)
+}
+
+// matches reports whether the directive fires for the trigger on the scene, its guard peeks the state
+// of the scene
+func (d *SceneDirector[T]) matches(directive Directive[T], trigger SceneTransitionTrigger, scene Scene[T]) bool {
+	return directive.Trigger == trigger && (directive.Guard == nil || directive.Guard(d.peek(scene)))
+}
+
+// ProcessTrigger fires the first directive of the current scene matching the trigger. It returns the
+// directive fired, or nil if none matched, and an error if the destination of the directive can't be
+// built. While a transition is running it returns ErrTriggerQueued if the interrupt policy queues the
+// trigger and ErrTriggerHeldBack if the policy drops it. If no directive matches and the director
+// is nested in another one, the trigger is processed by the parent, with the policy of the parent
+func (d *SceneDirector[T]) ProcessTrigger(trigger SceneTransitionTrigger) (*Directive[T], error) {
+	return d.processTrigger(trigger, nil, nil)
+}
+
+// ProcessTriggerWithPolicy processes the trigger applying the policy instead of the one of the director
+// if a transition is running. A queued trigger is processed against the scene reached by the transition.
+// The policy also applies to the parent if the trigger bubbles up, otherwise each director applies its own
+func (d *SceneDirector[T]) ProcessTriggerWithPolicy(trigger SceneTransitionTrigger, policy InterruptPolicy) (*Directive[T], error) {
+	return d.processTrigger(trigger, nil, &policy)
+}
+
+// ProcessTriggerWithPayload processes the trigger delivering the payload to the destination, which
+// receives it if it's a PayloadScene, and merging it into the state with the payload reducer. A nil
+// payload is not delivered
+func (d *SceneDirector[T]) ProcessTriggerWithPayload(trigger SceneTransitionTrigger, payload any) (*Directive[T], error) {
+	return d.processTrigger(trigger, payload, nil)
+}
+
+// SetPayloadReducer sets the function that merges the payloads into the state given to the destination
+func (d *SceneDirector[T]) SetPayloadReducer(reducer func(state T, payload any) T) {
+	d.reducer = reducer
+}
+
+// processTrigger applies the policy, or the one of the director if it's nil, to the running transition
+func (d *SceneDirector[T]) processTrigger(trigger SceneTransitionTrigger, payload any, policy *InterruptPolicy) (*Directive[T], error) {
+	applied := d.policy
+	if policy != nil {
+		applied = *policy
+	}
+	origin, ok := d.interrupt(applied, func() { d.processTrigger(trigger, payload, policy) })
+	if !ok {
+		if applied == InterruptQueue {
+			return nil, ErrTriggerQueued
+		}
+		return nil, ErrTriggerHeldBack
+	}
+
+	for _, directive := range d.directives(unwrapScene(origin)) {
+		if d.matches(*directive, trigger, origin) {
+			dest, err := d.destination(*directive)
+			if err != nil {
+				return nil, err
+			}
+			d.deliver(dest, payload)
+			d.switchFrom(d, origin, dest, directive.Transition)
+			return directive, nil
+		}
+	}
+	if d.parent != nil {
+		// Triggers the director doesn't handle bubble up to the director it's nested in
+		return d.parent.processTrigger(trigger, payload, policy)
+	}
+	return nil, nil
+}
+
+// deliver gives the payload to the scene and merges it into the states the scene is loaded with
+// until the switch finishes
+func (d *SceneDirector[T]) deliver(scene Scene[T], payload any) {
+	if payload == nil {
+		return
+	}
+	if p, ok := scene.(PayloadScene[T]); ok {
+		p.ReceivePayload(payload)
+	}
+	if d.reducer != nil {
+		d.arriving = func(state T) T { return d.reducer(state, payload) }
+	}
+}
+
+// PayloadReducer adapts a reducer of payloads of type P for SetPayloadReducer, other payloads leave the
+// state unchanged
+func PayloadReducer[T, P any](reducer func(state T, payload P) T) func(state T, payload any) T {
+	return func(state T, payload any) T {
+		if p, ok := payload.(P); ok {
+			return reducer(state, p)
+		}
+		return state
+	}
+}
+
+// Load starts the director as a scene of another director, so a node of the parent flow can have a
+// flow of its own. The director restarts at the scene it started at, loaded with the state, and the
+// triggers it doesn't handle bubble up to the parent. Directors built with NewSceneDirector already
+// loaded their start scene, use NewNestedSceneDirector so it's only loaded here
+func (d *SceneDirector[T]) Load(state T, sm SceneController[T]) {
+	d.parent, _ = sm.(*SceneDirector[T])
+	d.current = d.start
+	d.setActive(d.start, true)
+	d.load(d.start, state, d)
+}
+
+// Unload ends the running transition, if any, and unloads the current scene, its state is handed to
+// the next scene of the parent. Triggers no longer bubble up to the parent once it's left
+func (d *SceneDirector[T]) Unload() T {
+	d.parent = nil
+	scene, _ := d.interrupt(InterruptSnap, nil)
+	d.setActive(scene, false)
+	return unwrapScene(scene).Unload()
 }
@@ -123,3 +123,395 @@ func TestSceneDirector_ProcessTriggerCancellingToNewTransition(t *testing.T) {
 	mockTransitionB.End()
 	assert.Equal(t, mockSceneA, director.current)
 }
+
+type MockStatefulScene struct {
+	MockScene
+}
+
+func (m *MockStatefulScene) State() int {
+	return m.unloadReturns
+}
+
+func TestSceneDirector_ProcessTriggerGuarded(t *testing.T) {
+	menu := &MockStatefulScene{}
+	tutorial, levels := &MockScene{}, &MockScene{}
+	tutorialDone := func(state int) bool { return state > 0 }
+	ruleSet := map[Scene[int]][]Directive[int]{
+		menu: {
+			{Dest: levels, Trigger: 1, Guard: tutorialDone},
+			{Dest: tutorial, Trigger: 1, Guard: func(state int) bool { return !tutorialDone(state) }},
+		},
+	}
+	director := NewSceneDirector[int](menu, 0, ruleSet)
+
+	// The guard peeks the state without unloading the scene
+	assert.False(t, director.matches(ruleSet[menu][0], 1, menu))
+	menu.unloadReturns = 1
+	assert.True(t, director.matches(ruleSet[menu][0], 1, menu))
+	assert.False(t, menu.unloadCalled)
+
+	director.ProcessTrigger(1)
+	assert.Same(t, levels, director.current)
+}
+
+func TestSceneManager_State(t *testing.T) {
+	scene := &MockScene{}
+	sm := NewSceneManager[int](scene, 3)
+	assert.Equal(t, 3, sm.State())
+
+	// Scenes that don't expose their state report the state they were loaded with
+	scene.unloadReturns = 4
+	dest := &MockStatefulScene{}
+	trans := &baseTransitionImplementation{}
+	sm.SwitchWithTransition(dest, trans)
+	assert.Equal(t, 4, sm.State())
+
+	trans.End()
+	dest.unloadReturns = 5
+	assert.Equal(t, 5, sm.State())
+}
+
+func TestSceneDirector_ProcessTriggerFirstMatch(t *testing.T) {
+	sceneA, sceneB, sceneC := &MockScene{}, &MockScene{}, &MockScene{}
+	trans := &baseTransitionImplementation{}
+	ruleSet := map[Scene[int]][]Directive[int]{
+		sceneA: {
+			{Dest: sceneB, Trigger: 1, Transition: trans},
+			{Dest: sceneC, Trigger: 1},
+		},
+	}
+	director := NewSceneDirector[int](sceneA, 0, ruleSet)
+
+	// Only the first matching directive fires
+	fired, err := director.ProcessTrigger(1)
+	assert.NoError(t, err)
+	assert.Same(t, &ruleSet[sceneA][0], fired)
+	assert.Same(t, trans, director.current)
+	assert.False(t, sceneC.loadCalled)
+
+	trans.End()
+	fired, err = director.ProcessTrigger(1)
+	assert.NoError(t, err)
+	assert.Nil(t, fired)
+	assert.Same(t, sceneB, director.current)
+}
+
+func TestSceneDirector_ProcessTriggerPriority(t *testing.T) {
+	sceneA, sceneB, sceneC := &MockScene{}, &MockScene{}, &MockScene{}
+	ruleSet := map[Scene[int]][]Directive[int]{
+		sceneA: {
+			{Dest: sceneB, Trigger: 1},
+			{Dest: sceneC, Trigger: 1, Priority: 1},
+		},
+	}
+	director := NewSceneDirector[int](sceneA, 0, ruleSet)
+
+	fired, err := director.ProcessTrigger(1)
+	assert.NoError(t, err)
+	assert.Same(t, &ruleSet[sceneA][1], fired)
+	assert.Same(t, sceneC, director.current)
+	assert.False(t, sceneB.loadCalled)
+}
+
+func TestSceneDirector_ProcessTriggerHeldBack(t *testing.T) {
+	sceneA := &MockScene{}
+	trans := &baseTransitionImplementation{}
+	ruleSet := map[Scene[int]][]Directive[int]{
+		sceneA: {{Dest: &MockScene{}, Trigger: 1, Transition: trans}},
+	}
+	director := NewSceneDirector[int](sceneA, 0, ruleSet)
+	director.ProcessTrigger(1)
+
+	fired, err := director.ProcessTriggerWithPolicy(1, InterruptIgnore)
+	assert.ErrorIs(t, err, ErrTriggerHeldBack)
+	assert.Nil(t, fired)
+	assert.Same(t, trans, director.current)
+
+	fired, err = director.ProcessTriggerWithPolicy(1, InterruptReverse)
+	assert.ErrorIs(t, err, ErrTriggerHeldBack)
+	assert.Nil(t, fired)
+
+	director.ProcessTrigger(1)
+	fired, err = director.ProcessTriggerWithPolicy(2, InterruptQueue)
+	assert.ErrorIs(t, err, ErrTriggerQueued)
+	assert.Nil(t, fired)
+
+	// No directive matching is not an error
+	trans.End()
+	fired, err = director.ProcessTrigger(2)
+	assert.NoError(t, err)
+	assert.Nil(t, fired)
+}
+
+func TestSceneDirector_GlobalAndGroupRules(t *testing.T) {
+	title, level, boss, settings := &MockScene{}, &MockScene{}, &MockScene{}, &MockScene{}
+	director := NewSceneDirector[int](level, 0, map[Scene[int]][]Directive[int]{
+		boss: {{Dest: level, Trigger: 1}},
+	})
+	director.Tag(level, "gameplay")
+	director.Tag(boss, "gameplay", "boss")
+	director.GroupRuleSet = map[string][]Directive[int]{
+		"gameplay": {{Dest: title, Trigger: 1}, {Dest: boss, Trigger: 3, Except: []string{"boss"}}},
+	}
+	director.GlobalRuleSet = []Directive[int]{
+		{Dest: settings, Trigger: 2},
+		{Dest: title, Trigger: 1},
+	}
+
+	// Scene rules come before group rules, which come before global rules
+	directives := director.directives(boss)
+	assert.Len(t, directives, 4)
+	assert.Same(t, level, directives[0].Dest)
+	assert.Same(t, title, directives[1].Dest)
+	assert.Same(t, settings, directives[2].Dest)
+
+	// Excepted groups are left out
+	for _, directive := range directives {
+		assert.NotEqual(t, SceneTransitionTrigger(3), directive.Trigger)
+	}
+	fired, _ := director.ProcessTrigger(3)
+	assert.Same(t, &director.GroupRuleSet["gameplay"][1], fired)
+	assert.Same(t, boss, director.current)
+
+	director.ProcessTrigger(1)
+	assert.Same(t, level, director.current)
+	director.ProcessTrigger(2)
+	assert.Same(t, settings, director.current)
+	fired, _ = director.ProcessTrigger(1)
+	assert.Same(t, &director.GlobalRuleSet[1], fired)
+	assert.Same(t, title, director.current)
+}
+
+func TestSceneDirector_GlobalRulesPriority(t *testing.T) {
+	sceneA, sceneB, pause := &MockScene{}, &MockScene{}, &MockScene{}
+	director := NewSceneDirector[int](sceneA, 0, map[Scene[int]][]Directive[int]{
+		sceneA: {{Dest: sceneB, Trigger: 1}},
+	})
+	director.GlobalRuleSet = []Directive[int]{{Dest: pause, Trigger: 1, Priority: 1}}
+
+	director.ProcessTrigger(1)
+	assert.Same(t, pause, director.current)
+}
+
+func TestSceneDirector_IDGroups(t *testing.T) {
+	r := NewRegistry[int]()
+	r.RegisterSingleton("menu", func() Scene[int] { return &MockScene{} })
+	r.RegisterSingleton("level", func() Scene[int] { return &MockScene{} })
+	director, _ := NewSceneDirectorWithRegistry[int](r, "level", 0, nil)
+	director.TagID("level", "gameplay")
+	director.GlobalRuleSet = []Directive[int]{{DestID: "menu", Trigger: 1, Except: []string{"menu"}}}
+
+	level, _ := r.Resolve("level")
+	assert.Equal(t, []string{"gameplay", "level"}, director.groups(level))
+
+	director.ProcessTrigger(1)
+	id, _ := director.CurrentID()
+	assert.Equal(t, SceneID("menu"), id)
+
+	// Scenes are in the group named after their ID
+	fired, err := director.ProcessTrigger(1)
+	assert.NoError(t, err)
+	assert.Nil(t, fired)
+}
+
+type MockPayloadScene struct {
+	MockScene
+	payload any
+}
+
+func (m *MockPayloadScene) ReceivePayload(payload any) {
+	m.payload = payload
+}
+
+func TestSceneDirector_ProcessTriggerWithPayload(t *testing.T) {
+	menu, levels := &MockScene{}, &MockPayloadScene{}
+	trans := &baseTransitionImplementation{}
+	director := NewSceneDirector[int](menu, 1, map[Scene[int]][]Directive[int]{
+		menu:   {{Dest: levels, Trigger: 1, Transition: trans}},
+		levels: {{Dest: menu, Trigger: 2}},
+	})
+	director.SetPayloadReducer(PayloadReducer(func(state int, world int) int { return state + world*10 }))
+
+	fired, err := director.ProcessTriggerWithPayload(1, 3)
+	assert.NoError(t, err)
+	assert.NotNil(t, fired)
+	assert.Equal(t, 3, levels.payload)
+	assert.Equal(t, 31, levels.unloadReturns)
+
+	// The payload is merged again when the transition finishes
+	trans.End()
+	assert.Equal(t, 31, levels.unloadReturns)
+	assert.Equal(t, 31, director.State())
+
+	// Later switches are not affected
+	director.ProcessTrigger(2)
+	assert.Equal(t, 31, menu.unloadReturns)
+
+	// Payloads of other types leave the state unchanged
+	director.ProcessTriggerWithPayload(1, "world 3")
+	assert.Equal(t, "world 3", levels.payload)
+	assert.Equal(t, 31, levels.unloadReturns)
+}
+
+func TestSceneDirector_ProcessTriggerWithPayloadQueued(t *testing.T) {
+	sceneA, sceneB, sceneC := &MockScene{}, &MockScene{}, &MockPayloadScene{}
+	trans := &baseTransitionImplementation{}
+	director := NewSceneDirector[int](sceneA, 0, map[Scene[int]][]Directive[int]{
+		sceneA: {{Dest: sceneB, Trigger: 1, Transition: trans}},
+		sceneB: {{Dest: sceneC, Trigger: 2}},
+	})
+	director.SetInterruptPolicy(InterruptQueue)
+	director.SetPayloadReducer(func(state int, payload any) int { return payload.(int) })
+
+	director.ProcessTrigger(1)
+	director.ProcessTriggerWithPayload(2, 7)
+	assert.Nil(t, sceneC.payload)
+
+	trans.End()
+	assert.Same(t, sceneC, director.current)
+	assert.Equal(t, 7, sceneC.payload)
+	assert.Equal(t, 7, sceneC.unloadReturns)
+}
+
+func TestSceneDirector_Nested(t *testing.T) {
+	menu, explore, credits := &MockScene{}, &MockScene{}, &MockScene{}
+	combat := &MockStatefulScene{}
+	gameplay := NewNestedSceneDirector[int](explore, map[Scene[int]][]Directive[int]{
+		explore: {{Dest: combat, Trigger: 2}},
+		combat:  {{Dest: explore, Trigger: 3}},
+	})
+	var _ StatefulScene[int] = gameplay
+	trans := &baseTransitionImplementation{}
+	director := NewSceneDirector[int](menu, 1, map[Scene[int]][]Directive[int]{
+		menu:     {{Dest: gameplay, Trigger: 1}},
+		gameplay: {{Dest: credits, Trigger: 4, Transition: trans, Guard: func(state int) bool { return state > 1 }}},
+	})
+
+	// The state flows into the start scene of the nested director
+	director.ProcessTrigger(1)
+	assert.Same(t, gameplay, director.current)
+	assert.Same(t, director, gameplay.parent)
+	assert.Equal(t, 1, explore.unloadReturns)
+
+	fired, err := gameplay.ProcessTrigger(2)
+	assert.NoError(t, err)
+	assert.Same(t, &gameplay.RuleSet[explore][0], fired)
+	assert.Same(t, combat, gameplay.current)
+
+	// Unhandled triggers bubble up to the parent, whose guards peek the nested scene
+	fired, err = gameplay.ProcessTrigger(4)
+	assert.NoError(t, err)
+	assert.Nil(t, fired)
+	combat.unloadReturns = 2
+	fired, err = gameplay.ProcessTrigger(4)
+	assert.NoError(t, err)
+	assert.Same(t, &director.RuleSet[gameplay][0], fired)
+	assert.Same(t, trans, director.current)
+	assert.True(t, combat.unloadCalled)
+	assert.Equal(t, 2, credits.unloadReturns)
+
+	// Entering again restarts the nested flow
+	trans.End()
+	director.RuleSet[credits] = []Directive[int]{{Dest: gameplay, Trigger: 1}}
+	credits.unloadReturns = 5
+	director.ProcessTrigger(1)
+	assert.Same(t, explore, gameplay.current)
+	assert.Equal(t, 5, explore.unloadReturns)
+
+	// Triggers the top director doesn't handle are dropped
+	fired, err = gameplay.ProcessTrigger(9)
+	assert.NoError(t, err)
+	assert.Nil(t, fired)
+}
+
+func TestSceneDirector_NestedUnloadEndsTransition(t *testing.T) {
+	explore, combat, menu := &MockScene{}, &MockScene{}, &MockScene{}
+	trans := &baseTransitionImplementation{}
+	gameplay := NewNestedSceneDirector[int](explore, map[Scene[int]][]Directive[int]{
+		explore: {{Dest: combat, Trigger: 2, Transition: trans}},
+	})
+	director := NewSceneDirector[int](gameplay, 1, map[Scene[int]][]Directive[int]{
+		gameplay: {{Dest: menu, Trigger: 3}},
+	})
+
+	explore.unloadReturns = 4
+	gameplay.ProcessTrigger(2)
+	assert.Same(t, trans, gameplay.current)
+	director.ProcessTrigger(3)
+	assert.Same(t, menu, director.current)
+	assert.Same(t, combat, gameplay.current)
+	assert.True(t, combat.unloadCalled)
+	assert.Equal(t, 4, menu.unloadReturns)
+
+	// The director left doesn't bubble triggers up to its former parent
+	assert.Nil(t, gameplay.parent)
+	director.RuleSet[menu] = []Directive[int]{{Dest: gameplay, Trigger: 5}}
+	fired, err := gameplay.ProcessTrigger(5)
+	assert.NoError(t, err)
+	assert.Nil(t, fired)
+	assert.Same(t, menu, director.current)
+}
+
+func TestSceneDirector_NestedBubblesWithParentPolicy(t *testing.T) {
+	menu, explore, credits := &MockScene{}, &MockScene{}, &MockScene{}
+	gameplay := NewNestedSceneDirector[int](explore, nil)
+	trans := &baseTransitionImplementation{}
+	director := NewSceneDirector[int](menu, 0, map[Scene[int]][]Directive[int]{
+		menu:     {{Dest: gameplay, Trigger: 1, Transition: trans}},
+		gameplay: {{Dest: credits, Trigger: 2}},
+	})
+	director.SetInterruptPolicy(InterruptIgnore)
+
+	// The parent is still switching to the nested director, which snaps by default
+	director.ProcessTrigger(1)
+	assert.Same(t, trans, director.current)
+	assert.Same(t, director, gameplay.parent)
+
+	fired, err := gameplay.ProcessTrigger(2)
+	assert.ErrorIs(t, err, ErrTriggerHeldBack)
+	assert.Nil(t, fired)
+	assert.Same(t, trans, director.current)
+
+	// An explicit policy applies to the parent too
+	fired, err = gameplay.ProcessTriggerWithPolicy(2, InterruptSnap)
+	assert.NoError(t, err)
+	assert.Same(t, &director.RuleSet[gameplay][0], fired)
+	assert.Same(t, credits, director.current)
+}
+
+func TestSceneDirector_NestedSwitchTo(t *testing.T) {
+	menu, explore, credits := &MockScene{}, &MockScene{}, &MockScene{}
+	gameplay := NewNestedSceneDirector[int](explore, nil)
+	director := NewSceneDirector[int](menu, 0, map[Scene[int]][]Directive[int]{
+		gameplay: {{Dest: credits, Trigger: 2}},
+	})
+
+	// Switches inherited from the manager hand the director to the scenes too
+	director.SwitchTo(gameplay)
+	assert.Same(t, director, gameplay.parent)
+	fired, err := gameplay.ProcessTrigger(2)
+	assert.NoError(t, err)
+	assert.Same(t, &director.RuleSet[gameplay][0], fired)
+	assert.Same(t, credits, director.current)
+
+	trans := &baseTransitionImplementation{}
+	director.SwitchWithTransition(gameplay, trans)
+	assert.Same(t, director, trans.sm)
+	trans.End()
+	assert.Same(t, gameplay, director.current)
+	assert.Same(t, director, gameplay.parent)
+}
+
+func TestSceneDirector_NestedLoadsOnce(t *testing.T) {
+	menu, explore := &MockScene{}, &countingScene{}
+	gameplay := NewNestedSceneDirector[int](explore, nil)
+	assert.Empty(t, explore.loads)
+	assert.NotPanics(t, func() { gameplay.Validate() })
+
+	director := NewSceneDirector[int](menu, 3, map[Scene[int]][]Directive[int]{
+		menu: {{Dest: gameplay, Trigger: 1}},
+	})
+	director.ProcessTrigger(1)
+	assert.Equal(t, []int{3}, explore.loads)
+	assert.Same(t, explore, gameplay.current)
+}
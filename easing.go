@@ -0,0 +1,195 @@
+package stagehand
+
+import (
+	"math"
+	"strings"
+
+	"github.com/joelschutz/stagehand/ruleset"
+)
+
+// An Easing maps the linear progress of a transition, from 0 to 1, to the progress that is drawn.
+// The result may fall outside of the [0, 1] range for curves that overshoot, like Back and Elastic.
+type Easing func(float64) float64
+
+// Linear is the identity easing, it's used when no easing is given
+func Linear(x float64) float64 { return x }
+
+// EaseOut mirrors an ease-in curve to its ease-out counterpart
+func EaseOut(in Easing) Easing {
+	return func(x float64) float64 { return 1 - in(1-x) }
+}
+
+// EaseInOut joins an ease-in curve and its mirror in a single curve
+func EaseInOut(in Easing) Easing {
+	return func(x float64) float64 {
+		if x < .5 {
+			return in(2*x) / 2
+		}
+		return 1 - in(2-2*x)/2
+	}
+}
+
+// Polynomial curves
+func EaseInQuad(x float64) float64    { return x * x }
+func EaseOutQuad(x float64) float64   { return EaseOut(EaseInQuad)(x) }
+func EaseInOutQuad(x float64) float64 { return EaseInOut(EaseInQuad)(x) }
+
+func EaseInCubic(x float64) float64    { return x * x * x }
+func EaseOutCubic(x float64) float64   { return EaseOut(EaseInCubic)(x) }
+func EaseInOutCubic(x float64) float64 { return EaseInOut(EaseInCubic)(x) }
+
+func EaseInQuint(x float64) float64    { return x * x * x * x * x }
+func EaseOutQuint(x float64) float64   { return EaseOut(EaseInQuint)(x) }
+func EaseInOutQuint(x float64) float64 { return EaseInOut(EaseInQuint)(x) }
+
+// Sinusoidal curves
+func EaseInSine(x float64) float64    { return 1 - math.Cos(x*math.Pi/2) }
+func EaseOutSine(x float64) float64   { return math.Sin(x * math.Pi / 2) }
+func EaseInOutSine(x float64) float64 { return -(math.Cos(x*math.Pi) - 1) / 2 }
+
+// Exponential curves
+func EaseInExpo(x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	return math.Pow(2, 10*x-10)
+}
+func EaseOutExpo(x float64) float64   { return EaseOut(EaseInExpo)(x) }
+func EaseInOutExpo(x float64) float64 { return EaseInOut(EaseInExpo)(x) }
+
+// Back curves overshoot slightly before settling
+func EaseInBack(x float64) float64 {
+	const c1 = 1.70158
+	return (c1+1)*x*x*x - c1*x*x
+}
+func EaseOutBack(x float64) float64 { return EaseOut(EaseInBack)(x) }
+func EaseInOutBack(x float64) float64 {
+	const c2 = 1.70158 * 1.525
+	if x < .5 {
+		return math.Pow(2*x, 2) * ((c2+1)*2*x - c2) / 2
+	}
+	return (math.Pow(2*x-2, 2)*((c2+1)*(x*2-2)+c2) + 2) / 2
+}
+
+// Elastic curves oscillate around the start or the end of the progress
+func EaseInElastic(x float64) float64 {
+	const c4 = 2 * math.Pi / 3
+	if x <= 0 || x >= 1 {
+		return math.Max(0, math.Min(1, x))
+	}
+	return -math.Pow(2, 10*x-10) * math.Sin((x*10-10.75)*c4)
+}
+func EaseOutElastic(x float64) float64 { return EaseOut(EaseInElastic)(x) }
+func EaseInOutElastic(x float64) float64 {
+	const c5 = 2 * math.Pi / 4.5
+	switch {
+	case x <= 0:
+		return 0
+	case x >= 1:
+		return 1
+	case x < .5:
+		return -(math.Pow(2, 20*x-10) * math.Sin((20*x-11.125)*c5)) / 2
+	}
+	return math.Pow(2, -20*x+10)*math.Sin((20*x-11.125)*c5)/2 + 1
+}
+
+// Bounce curves bounce off the end of the progress
+func EaseOutBounce(x float64) float64 {
+	const n1, d1 = 7.5625, 2.75
+	switch {
+	case x < 1/d1:
+		return n1 * x * x
+	case x < 2/d1:
+		x -= 1.5 / d1
+		return n1*x*x + .75
+	case x < 2.5/d1:
+		x -= 2.25 / d1
+		return n1*x*x + .9375
+	}
+	x -= 2.625 / d1
+	return n1*x*x + .984375
+}
+func EaseInBounce(x float64) float64    { return EaseOut(EaseOutBounce)(x) }
+func EaseInOutBounce(x float64) float64 { return EaseInOut(EaseInBounce)(x) }
+
+// CubicBezier returns a curve defined by the control points (x1, y1) and (x2, y2) like the CSS
+// cubic-bezier function, x1 and x2 must be in the [0, 1] range
+func CubicBezier(x1, y1, x2, y2 float64) Easing {
+	bezier := func(t, p1, p2 float64) float64 {
+		return 3*(1-t)*(1-t)*t*p1 + 3*(1-t)*t*t*p2 + t*t*t
+	}
+	slope := func(t, p1, p2 float64) float64 {
+		return 3*(1-t)*(1-t)*p1 + 6*(1-t)*t*(p2-p1) + 3*t*t*(1-p2)
+	}
+	return func(x float64) float64 {
+		if x <= 0 || x >= 1 {
+			return math.Max(0, math.Min(1, x))
+		}
+		// Find the parameter for x with Newton's method falling back to bisection
+		t := x
+		for i := 0; i < 8; i++ {
+			d := slope(t, x1, x2)
+			if math.Abs(d) < 1e-6 {
+				break
+			}
+			t -= (bezier(t, x1, x2) - x) / d
+		}
+		if t < 0 || t > 1 || math.Abs(bezier(t, x1, x2)-x) > 1e-6 {
+			lo, hi := 0., 1.
+			for i := 0; i < 32; i++ {
+				t = (lo + hi) / 2
+				if bezier(t, x1, x2) < x {
+					lo = t
+				} else {
+					hi = t
+				}
+			}
+		}
+		return bezier(t, y1, y2)
+	}
+}
+
+// Steps returns a curve that jumps between n discrete steps, like the CSS steps function
+func Steps(n int) Easing {
+	return func(x float64) float64 {
+		if x >= 1 {
+			return 1
+		}
+		return math.Floor(x*float64(n)) / float64(n)
+	}
+}
+
+var easings = map[string]Easing{
+	"linear":       Linear,
+	"inquad":       EaseInQuad,
+	"outquad":      EaseOutQuad,
+	"inoutquad":    EaseInOutQuad,
+	"incubic":      EaseInCubic,
+	"outcubic":     EaseOutCubic,
+	"inoutcubic":   EaseInOutCubic,
+	"inquint":      EaseInQuint,
+	"outquint":     EaseOutQuint,
+	"inoutquint":   EaseInOutQuint,
+	"insine":       EaseInSine,
+	"outsine":      EaseOutSine,
+	"inoutsine":    EaseInOutSine,
+	"inexpo":       EaseInExpo,
+	"outexpo":      EaseOutExpo,
+	"inoutexpo":    EaseInOutExpo,
+	"inback":       EaseInBack,
+	"outback":      EaseOutBack,
+	"inoutback":    EaseInOutBack,
+	"inelastic":    EaseInElastic,
+	"outelastic":   EaseOutElastic,
+	"inoutelastic": EaseInOutElastic,
+	"inbounce":     EaseInBounce,
+	"outbounce":    EaseOutBounce,
+	"inoutbounce":  EaseInOutBounce,
+}
+
+// EasingByName returns the built-in curve with the name, like "outCubic" or "ease-in-out-sine". Names
+// are case insensitive and the "ease" prefix is optional
+func EasingByName(name string) (Easing, bool) {
+	easing, ok := easings[strings.TrimPrefix(ruleset.NormalizeName(name), "ease")]
+	return easing, ok
+}
@@ -0,0 +1,77 @@
+package stagehand
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEasing_Endpoints(t *testing.T) {
+	tests := []struct {
+		name   string
+		easing Easing
+	}{
+		{"Linear", Linear},
+		{"EaseInQuad", EaseInQuad},
+		{"EaseOutQuad", EaseOutQuad},
+		{"EaseInOutQuad", EaseInOutQuad},
+		{"EaseInCubic", EaseInCubic},
+		{"EaseOutCubic", EaseOutCubic},
+		{"EaseInOutCubic", EaseInOutCubic},
+		{"EaseInQuint", EaseInQuint},
+		{"EaseOutQuint", EaseOutQuint},
+		{"EaseInOutQuint", EaseInOutQuint},
+		{"EaseInSine", EaseInSine},
+		{"EaseOutSine", EaseOutSine},
+		{"EaseInOutSine", EaseInOutSine},
+		{"EaseInExpo", EaseInExpo},
+		{"EaseOutExpo", EaseOutExpo},
+		{"EaseInOutExpo", EaseInOutExpo},
+		{"EaseInBack", EaseInBack},
+		{"EaseOutBack", EaseOutBack},
+		{"EaseInOutBack", EaseInOutBack},
+		{"EaseInElastic", EaseInElastic},
+		{"EaseOutElastic", EaseOutElastic},
+		{"EaseInOutElastic", EaseInOutElastic},
+		{"EaseInBounce", EaseInBounce},
+		{"EaseOutBounce", EaseOutBounce},
+		{"EaseInOutBounce", EaseInOutBounce},
+		{"CubicBezier", CubicBezier(.25, .1, .25, 1)},
+		{"Steps", Steps(4)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, 0, tt.easing(0), 1e-9)
+			assert.InDelta(t, 1, tt.easing(1), 1e-9)
+		})
+	}
+}
+
+func TestEasing_Shape(t *testing.T) {
+	assert.Less(t, EaseInCubic(.5), .5)
+	assert.Greater(t, EaseOutCubic(.5), .5)
+	assert.InDelta(t, .5, EaseInOutCubic(.5), 1e-9)
+
+	// Back and elastic curves overshoot
+	assert.Less(t, EaseInBack(.2), 0.)
+	assert.Greater(t, EaseOutBack(.8), 1.)
+	assert.Greater(t, EaseOutElastic(.2), 1.)
+}
+
+func TestCubicBezier(t *testing.T) {
+	linear := CubicBezier(0, 0, 1, 1)
+	for _, x := range []float64{.1, .25, .5, .75, .9} {
+		assert.InDelta(t, x, linear(x), 1e-4)
+	}
+
+	ease := CubicBezier(.42, 0, .58, 1)
+	assert.InDelta(t, .5, ease(.5), 1e-4)
+	assert.Less(t, ease(.2), .2)
+}
+
+func TestSteps(t *testing.T) {
+	steps := Steps(4)
+	assert.Equal(t, 0., steps(.2))
+	assert.Equal(t, .25, steps(.3))
+	assert.Equal(t, .75, steps(.99))
+}
@@ -0,0 +1,33 @@
+package stagehand
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// typeName returns the name of the type of v without its package and type parameters
+func typeName(v any) string {
+	name := strings.TrimPrefix(fmt.Sprintf("%T", v), "*")
+	if i := strings.IndexByte(name, '['); i >= 0 {
+		name = name[:i]
+	}
+	return name[strings.LastIndexByte(name, '.')+1:]
+}
+
+// WriteDOT writes the flow between the scenes as a Graphviz DOT digraph. Edges are labeled with the
+// trigger and the transition of the directives, and the current scene is highlighted
+func (d *SceneDirector[T]) WriteDOT(w io.Writer) error {
+	return d.graph().WriteDOT(w)
+}
+
+// WriteMermaid writes the flow between the scenes as a Mermaid state diagram. Edges are labeled with
+// the trigger and the transition of the directives, and the current scene is highlighted
+func (d *SceneDirector[T]) WriteMermaid(w io.Writer) error {
+	return d.graph().WriteMermaid(w)
+}
+
+// WriteText writes the flow between the scenes as plain text, every scene followed by its directives
+func (d *SceneDirector[T]) WriteText(w io.Writer) error {
+	return d.graph().WriteText(w)
+}
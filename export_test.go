@@ -0,0 +1,72 @@
+package stagehand
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newExportDirector(t *testing.T) *SceneDirector[int] {
+	r := NewRegistry[int]()
+	r.RegisterSingleton("level", func() Scene[int] { return &MockScene{} })
+	r.RegisterSingleton("menu", func() Scene[int] { return &MockScene{} })
+	triggers := NewTriggerRegistry()
+	play := triggers.Define("play")
+	director, err := NewSceneDirectorWithRegistry[int](r, "menu", 0, map[SceneID][]Directive[int]{
+		"menu": {
+			{DestID: "level", Trigger: play, Transition: NewFadeTransition[int](.05)},
+			{DestID: "menu", Trigger: play},
+		},
+		"level": {{DestID: "menu", Trigger: 7, Guard: func(int) bool { return true }}},
+	})
+	assert.NoError(t, err)
+	director.SetTriggerRegistry(triggers)
+	return director
+}
+
+func TestSceneDirector_WriteDOT(t *testing.T) {
+	director := newExportDirector(t)
+	var b strings.Builder
+	assert.NoError(t, director.WriteDOT(&b))
+	assert.Equal(t, `digraph stagehand {
+	rankdir=LR;
+	node [shape=box, style=rounded];
+	start [shape=point];
+	n0 [label="level"];
+	n1 [label="menu", style="rounded,filled", fillcolor=lightblue];
+	start -> n1;
+	n0 -> n1 [label="7 [guarded]"];
+	n1 -> n0 [label="play / FadeTransition"];
+}
+`, b.String())
+}
+
+func TestSceneDirector_WriteMermaid(t *testing.T) {
+	director := newExportDirector(t)
+	director.ProcessTriggerByName("play")
+
+	// No scene is highlighted while a transition is running
+	var b strings.Builder
+	assert.NoError(t, director.WriteMermaid(&b))
+	assert.NotContains(t, b.String(), "classDef")
+
+	director.current.(SceneTransition[int]).End()
+	b.Reset()
+	assert.NoError(t, director.WriteMermaid(&b))
+	assert.Equal(t, `stateDiagram-v2
+    state "level" as s0
+    state "menu" as s1
+    [*] --> s1
+    s0 --> s1 : 7 [guarded]
+    s1 --> s0 : play / FadeTransition
+    classDef current fill:#add8e6
+    class s0 current
+`, b.String())
+}
+
+func TestTypeName(t *testing.T) {
+	assert.Equal(t, "FadeTransition", typeName(&FadeTransition[int]{}))
+	assert.Equal(t, "MockScene", typeName(&MockScene{}))
+	assert.Equal(t, "int", typeName(1))
+}
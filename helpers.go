@@ -2,6 +2,7 @@ package stagehand
 
 import (
 	"image"
+	"math"
 	"time"
 
 	ebiten "github.com/hajimehoshi/ebiten/v2"
@@ -32,7 +33,8 @@ func MaxInt(a, b int) int {
 	return b
 }
 
-// Pre-draw returns the rendered frames for the given scenes.
+// Pre-draw returns the rendered frames for the given scenes. It allocates new images on every call,
+// transitions should use BaseTransition.PreDraw instead.
 func PreDraw[T any](bounds image.Rectangle, fromScene, toScene Scene[T]) (*ebiten.Image, *ebiten.Image) {
 	fromImg := ebiten.NewImage(bounds.Dx(), bounds.Dy())
 	fromScene.Draw(fromImg)
@@ -43,6 +45,33 @@ func PreDraw[T any](bounds image.Rectangle, fromScene, toScene Scene[T]) (*ebite
 	return toImg, fromImg
 }
 
+// A RenderTarget is an offscreen image reused across frames, it's only reallocated when the requested
+// size changes
+type RenderTarget struct {
+	img *ebiten.Image
+}
+
+// Image returns the cleared image with the given size
+func (r *RenderTarget) Image(width, height int) *ebiten.Image {
+	if r.img != nil {
+		if size := r.img.Bounds().Size(); size.X == width && size.Y == height {
+			r.img.Clear()
+			return r.img
+		}
+		r.img.Dispose()
+	}
+	r.img = ebiten.NewImage(width, height)
+	return r.img
+}
+
+// Dispose releases the image, a new one is allocated on the next call to Image
+func (r *RenderTarget) Dispose() {
+	if r.img != nil {
+		r.img.Dispose()
+		r.img = nil
+	}
+}
+
 // Converts a frequency(cycle/second) to a factor(change/cycle) for a given duration
 func DurationToFactor(frequency float64, duration time.Duration) float64 {
 	return (1 / frequency) / duration.Seconds()
@@ -52,3 +81,8 @@ func DurationToFactor(frequency float64, duration time.Duration) float64 {
 func CalculateProgress(initialTime time.Time, duration time.Duration) float64 {
 	return float64(Clock.Since(initialTime)) / float64(duration)
 }
+
+// Clamps an eased progress to a valid alpha value
+func clampAlpha(alpha float64) float32 {
+	return float32(math.Max(0, math.Min(1, alpha)))
+}
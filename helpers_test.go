@@ -88,3 +88,33 @@ func TestCalculateProgress(t *testing.T) {
 		})
 	}
 }
+
+func TestClampAlpha(t *testing.T) {
+	assert.Equal(t, float32(0), clampAlpha(-.1))
+	assert.Equal(t, float32(.5), clampAlpha(.5))
+	assert.Equal(t, float32(1), clampAlpha(1.1))
+}
+
+func TestRenderTarget(t *testing.T) {
+	var target RenderTarget
+	img := target.Image(10, 10)
+	assert.Same(t, img, target.Image(10, 10))
+
+	resized := target.Image(20, 10)
+	assert.NotSame(t, img, resized)
+	assert.Equal(t, image.Pt(20, 10), resized.Bounds().Size())
+
+	target.Dispose()
+	assert.Nil(t, target.img)
+	assert.NotNil(t, target.Image(10, 10))
+}
+
+func BenchmarkPreDraw(b *testing.B) {
+	from, to := &MockScene{}, &MockScene{}
+	bounds := image.Rect(0, 0, 320, 240)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		PreDraw[int](bounds, from, to)
+	}
+}
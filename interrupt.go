@@ -0,0 +1,99 @@
+package stagehand
+
+import ebiten "github.com/hajimehoshi/ebiten/v2"
+
+// An InterruptPolicy defines what happens when a switch is requested while a transition is running
+type InterruptPolicy int
+
+const (
+	// InterruptSnap ends the running transition at once and switches, it's the default
+	InterruptSnap InterruptPolicy = iota
+	// InterruptQueue switches once the running transition finishes
+	InterruptQueue
+	// InterruptIgnore drops the switch
+	InterruptIgnore
+	// InterruptReverse drops the switch and plays the running transition back to its origin, only
+	// transitions driven by a reversible Timeline are played back, the others return to the origin at
+	// once
+	InterruptReverse
+	// InterruptChain ends the running transition and starts the next one from its last frame
+	InterruptChain
+)
+
+// A snapshotScene draws the last frame of an interrupted transition in place of its destination, so
+// the next transition starts from what was on the screen
+type snapshotScene[T any] struct {
+	Scene[T]
+	img *ebiten.Image
+}
+
+func (s *snapshotScene[T]) Draw(screen *ebiten.Image) {
+	screen.DrawImage(s.img, nil)
+}
+
+func (s *snapshotScene[T]) unwrap() Scene[T] {
+	return s.Scene
+}
+
+// interrupt applies the policy to the running transition, if any. It returns the scene to switch from
+// and whether the switch should go on, a queued switch is retried once the transition finishes
+func (s *SceneManager[T]) interrupt(policy InterruptPolicy, retry func()) (Scene[T], bool) {
+	running, ok := s.current.(SceneTransition[T])
+	if !ok {
+		scene, ok := s.current.(Scene[T])
+		return scene, ok
+	}
+
+	var snapshot *RenderTarget
+	switch policy {
+	case InterruptQueue:
+		s.pending = append(s.pending, retry)
+		return nil, false
+	case InterruptIgnore:
+		return nil, false
+	case InterruptReverse:
+		s.reverse(running)
+		return nil, false
+	case InterruptChain:
+		if s.width > 0 && s.height > 0 {
+			// The running transition may be drawing the previous snapshot
+			snapshot = &s.snapshots[0]
+			if s.chained == snapshot {
+				snapshot = &s.snapshots[1]
+			}
+			running.Draw(snapshot.Image(s.width, s.height))
+		}
+	}
+
+	// The new switch replaces the queued ones
+	s.pending = nil
+	running.End()
+	scene, ok := s.current.(Scene[T])
+	if snapshot != nil {
+		if !ok {
+			snapshot.Dispose()
+			return scene, ok
+		}
+		s.chained = snapshot
+		return &snapshotScene[T]{Scene: scene, img: snapshot.img}, true
+	}
+	return scene, ok
+}
+
+// releaseSnapshot disposes the snapshot the running transition started from, once it's no longer drawn
+func (s *SceneManager[T]) releaseSnapshot() {
+	if s.chained != nil {
+		s.chained.Dispose()
+		s.chained = nil
+	}
+}
+
+// reverse plays the running transition back to its origin
+func (s *SceneManager[T]) reverse(running SceneTransition[T]) {
+	s.reverting = !s.reverting
+	if t, ok := running.(interface{ Timeline() *Timeline }); ok && t.Timeline() != nil && t.Timeline().Reversible() {
+		t.Timeline().Reverse()
+		return
+	}
+	running.End()
+}
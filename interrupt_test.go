@@ -0,0 +1,194 @@
+package stagehand
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSceneManager_InterruptSnap(t *testing.T) {
+	sm := NewSceneManager[int](&MockScene{}, 0)
+	assert.Equal(t, InterruptSnap, sm.InterruptPolicy())
+
+	sceneA, sceneB := &MockScene{}, &MockScene{}
+	transA, transB := &baseTransitionImplementation{}, &baseTransitionImplementation{}
+	sm.SwitchWithTransition(sceneA, transA)
+	sm.SwitchWithTransition(sceneB, transB)
+	assert.Equal(t, transB, sm.current)
+	assert.True(t, sceneA.unloadCalled)
+
+	transB.End()
+	assert.Equal(t, sceneB, sm.current)
+}
+
+func TestSceneManager_InterruptQueue(t *testing.T) {
+	sm := NewSceneManager[int](&MockScene{}, 0)
+	sm.SetInterruptPolicy(InterruptQueue)
+
+	sceneA, sceneB, sceneC := &MockScene{}, &MockScene{}, &MockScene{}
+	transA, transB := &baseTransitionImplementation{}, &baseTransitionImplementation{}
+	sm.SwitchWithTransition(sceneA, transA)
+	sm.SwitchWithTransition(sceneB, transB)
+	sm.SwitchTo(sceneC)
+	assert.Equal(t, transA, sm.current)
+	assert.False(t, sceneB.loadCalled)
+
+	// The queued switches run in order once each transition finishes
+	transA.End()
+	assert.Equal(t, transB, sm.current)
+	assert.Equal(t, sceneA, transB.fromScene)
+
+	transB.End()
+	assert.Equal(t, sceneC, sm.current)
+	assert.True(t, sceneB.unloadCalled)
+}
+
+func TestSceneManager_InterruptIgnore(t *testing.T) {
+	sm := NewSceneManager[int](&MockScene{}, 0)
+	sm.SetInterruptPolicy(InterruptIgnore)
+
+	sceneA, sceneB := &MockScene{}, &MockScene{}
+	transA := &baseTransitionImplementation{}
+	sm.SwitchWithTransition(sceneA, transA)
+	sm.SwitchTo(sceneB)
+	assert.Equal(t, transA, sm.current)
+
+	transA.End()
+	assert.Equal(t, sceneA, sm.current)
+	assert.False(t, sceneB.loadCalled)
+}
+
+func TestSceneManager_InterruptReverse(t *testing.T) {
+	origin := &MockTransitionAwareScene{}
+	sm := NewSceneManager[int](origin, 0)
+	sm.SetInterruptPolicy(InterruptReverse)
+	frame := runFrame[int](sm)
+
+	sceneA := &MockScene{}
+	trans := NewSlideTransition[int](LeftToRight, .25)
+	sm.SwitchWithTransition(sceneA, trans)
+	frame()
+	frame()
+	assert.Equal(t, .5, trans.offset)
+
+	// The transition plays back from where it was
+	sm.SwitchTo(&MockScene{})
+	frame()
+	assert.Equal(t, .25, trans.offset)
+	frame()
+	assert.Equal(t, 0., trans.offset)
+	assert.Equal(t, origin, sm.current)
+	assert.True(t, origin.postTransitionCalled)
+	assert.True(t, sceneA.unloadCalled)
+}
+
+func TestSceneManager_InterruptReverseReused(t *testing.T) {
+	sm := NewSceneManager[int](&MockScene{}, 0)
+	sm.SetInterruptPolicy(InterruptReverse)
+	frame := runFrame[int](sm)
+
+	trans := NewSlideTransition[int](LeftToRight, .25)
+	sm.SwitchWithTransition(&MockScene{}, trans)
+	frame()
+	sm.SwitchTo(&MockScene{})
+	frame()
+	assert.Equal(t, 0., trans.offset)
+
+	// The next run of the transition plays forwards
+	sm.SwitchWithTransition(&MockScene{}, trans)
+	frame()
+	assert.Equal(t, .25, trans.offset)
+}
+
+func TestSceneManager_InterruptReverseExternalTimeline(t *testing.T) {
+	origin := &MockScene{}
+	sm := NewSceneManager[int](origin, 0)
+
+	// External timelines follow their progress, so the transition returns to the origin at once
+	trans := &baseTransitionImplementation{}
+	trans.SetTimeline(NewExternalTimeline(func() float64 { return .5 }))
+	sm.SwitchWithTransition(&MockScene{}, trans)
+	sm.Update()
+	sm.SwitchWithPolicy(&MockScene{}, nil, InterruptReverse)
+	assert.Equal(t, origin, sm.current)
+	assert.False(t, sm.reverting)
+}
+
+func TestSceneManager_InterruptReverseWithoutTimeline(t *testing.T) {
+	origin := &MockScene{}
+	sm := NewSceneManager[int](origin, 0)
+
+	trans := &baseTransitionImplementation{}
+	sm.SwitchWithTransition(&MockScene{}, trans)
+	sm.SwitchWithPolicy(&MockScene{}, nil, InterruptReverse)
+	assert.Equal(t, origin, sm.current)
+	assert.False(t, sm.reverting)
+}
+
+func TestSceneManager_InterruptChain(t *testing.T) {
+	sm := NewSceneManager[int](&MockScene{}, 0)
+	sm.SetInterruptPolicy(InterruptChain)
+	sm.Layout(100, 100)
+
+	sceneA, sceneB := &MockScene{}, &MockScene{}
+	transA, transB := NewFadeTransition[int](.5), &baseTransitionImplementation{}
+	sm.SwitchWithTransition(sceneA, transA)
+	sm.Update()
+	sm.SwitchWithTransition(sceneB, transB)
+	assert.Equal(t, transB, sm.current)
+
+	// The next transition starts from the last frame of the interrupted one
+	snapshot, ok := transB.fromScene.(*snapshotScene[int])
+	assert.True(t, ok)
+	assert.Equal(t, sceneA, snapshot.Scene)
+	assert.Equal(t, 100, snapshot.img.Bounds().Dx())
+
+	// Chaining again draws into the other snapshot and releases the previous one
+	sceneC, transC := &MockScene{}, &baseTransitionImplementation{}
+	sm.SwitchWithTransition(sceneC, transC)
+	next := transC.fromScene.(*snapshotScene[int])
+	assert.NotSame(t, snapshot.img, next.img)
+	assert.Same(t, next.img, sm.chained.img)
+	assert.Nil(t, sm.snapshots[0].img)
+
+	// The snapshot is released when the chained transition ends
+	transC.End()
+	assert.Equal(t, sceneC, sm.current)
+	assert.True(t, sceneA.unloadCalled)
+	assert.True(t, sceneB.unloadCalled)
+	assert.Nil(t, sm.chained)
+	assert.Nil(t, sm.snapshots[1].img)
+}
+
+func TestSceneManager_InterruptChainWithoutTransition(t *testing.T) {
+	sm := NewSceneManager[int](&MockScene{}, 0)
+	sm.Layout(100, 100)
+
+	sceneA, sceneB := &MockScene{}, &MockScene{}
+	sm.SwitchWithTransition(sceneA, &baseTransitionImplementation{})
+	sm.SwitchWithPolicy(sceneB, nil, InterruptChain)
+	assert.Equal(t, sceneB, sm.current)
+	assert.True(t, sceneA.unloadCalled)
+	assert.Nil(t, sm.chained)
+	assert.Nil(t, sm.snapshots[0].img)
+}
+
+func TestSceneDirector_InterruptQueue(t *testing.T) {
+	sceneA, sceneB, sceneC := &MockScene{}, &MockScene{}, &MockScene{}
+	trans := &baseTransitionImplementation{}
+	ruleSet := map[Scene[int]][]Directive[int]{
+		sceneA: {{Dest: sceneB, Trigger: 1, Transition: trans}},
+		sceneB: {{Dest: sceneC, Trigger: 2}},
+	}
+	director := NewSceneDirector[int](sceneA, 0, ruleSet)
+	director.SetInterruptPolicy(InterruptIgnore)
+
+	director.ProcessTrigger(1)
+	director.ProcessTrigger(2)
+	director.ProcessTriggerWithPolicy(2, InterruptQueue)
+	assert.Equal(t, trans, director.current)
+
+	// The queued trigger is processed against the destination
+	trans.End()
+	assert.Equal(t, sceneC, director.current)
+}
@@ -0,0 +1,196 @@
+package stagehand
+
+import (
+	"image"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// A FocalScene is a Scene that tracks the focal point of iris transitions, e.g. the player position
+type FocalScene[T any] interface {
+	Scene[T]
+	FocalPoint() (x, y float64) // Position on the screen in pixels
+}
+
+// A Shape appends a closed shape centered at (x, y) to the path, the shape must cover the circle with
+// the given radius
+type Shape func(path *vector.Path, x, y, radius float32)
+
+// CircleShape is the classic iris
+func CircleShape(path *vector.Path, x, y, radius float32) {
+	path.Arc(x, y, radius, 0, 2*math.Pi, vector.Clockwise)
+	path.Close()
+}
+
+// DiamondShape is a square rotated by 45 degrees
+var DiamondShape = PolygonShape([2]float64{0, -1}, [2]float64{1, 0}, [2]float64{0, 1}, [2]float64{-1, 0})
+
+// StarShape returns a star with the given number of points, the inner vertices are placed at the
+// ratio of the outer ones
+func StarShape(points int, innerRatio float64) Shape {
+	vertices := make([][2]float64, 0, 2*points)
+	for i := 0; i < 2*points; i++ {
+		r := 1.
+		if i%2 == 1 {
+			r = innerRatio
+		}
+		a := math.Pi*float64(i)/float64(points) - math.Pi/2
+		vertices = append(vertices, [2]float64{r * math.Cos(a), r * math.Sin(a)})
+	}
+	return PolygonShape(vertices...)
+}
+
+// PolygonShape returns a shape with the given vertices around the origin, it's scaled so the
+// polygon covers the radius
+func PolygonShape(vertices ...[2]float64) Shape {
+	// Find the distance to the nearest edge
+	inner := math.Inf(1)
+	for i, a := range vertices {
+		b := vertices[(i+1)%len(vertices)]
+		dx, dy := b[0]-a[0], b[1]-a[1]
+		t := math.Max(0, math.Min(1, -(a[0]*dx+a[1]*dy)/(dx*dx+dy*dy)))
+		inner = math.Min(inner, math.Hypot(a[0]+t*dx, a[1]+t*dy))
+	}
+	return func(path *vector.Path, x, y, radius float32) {
+		scale := float64(radius) / inner
+		for i, v := range vertices {
+			vx, vy := x+float32(v[0]*scale), y+float32(v[1]*scale)
+			if i == 0 {
+				path.MoveTo(vx, vy)
+			} else {
+				path.LineTo(vx, vy)
+			}
+		}
+		path.Close()
+	}
+}
+
+type IrisMode int
+
+const (
+	IrisOpen  IrisMode = iota // The shape grows revealing the destination scene
+	IrisClose                 // The shape shrinks hiding the origin scene
+)
+
+var (
+	whiteImage    = ebiten.NewImage(3, 3)
+	whiteSubImage = whiteImage.SubImage(image.Rect(1, 1, 2, 2)).(*ebiten.Image)
+)
+
+func init() {
+	whiteImage.Fill(image.White)
+}
+
+// An IrisTransition reveals or hides a scene with a shape growing or shrinking around a focal point.
+// The focal point is tracked from the origin scene when it's a FocalScene, otherwise it's fixed.
+type IrisTransition[T any] struct {
+	BaseTransition[T]
+	shape        Shape
+	mode         IrisMode
+	focal        [2]float64 // fixed focal point, relative to the screen size
+	fixed        bool       // whether the focal point was set explicitly
+	mask         RenderTarget
+	vertices     []ebiten.Vertex
+	indices      []uint16
+	trianglesOp  ebiten.DrawTrianglesOptions
+	frameUpdated bool
+}
+
+func NewIrisTransition[T any](shape Shape, mode IrisMode, timeline *Timeline, opts ...TransitionOption) *IrisTransition[T] {
+	t := &IrisTransition[T]{
+		shape:       shape,
+		mode:        mode,
+		focal:       [2]float64{.5, .5},
+		trianglesOp: ebiten.DrawTrianglesOptions{FillRule: ebiten.EvenOdd, AntiAlias: true},
+	}
+	t.SetTimeline(timeline)
+	t.SetOptions(opts...)
+	return t
+}
+
+// SetFocalPoint fixes the focal point, relative to the screen size, (.5, .5) is the center
+func (t *IrisTransition[T]) SetFocalPoint(x, y float64) *IrisTransition[T] {
+	t.focal = [2]float64{x, y}
+	t.fixed = true
+	return t
+}
+
+// FocalPoint returns the current focal point on a screen of the given size
+func (t *IrisTransition[T]) FocalPoint(w, h int) (float64, float64) {
+	if c, ok := unwrapScene(t.fromScene).(FocalScene[T]); ok && !t.fixed {
+		return c.FocalPoint()
+	}
+	return t.focal[0] * float64(w), t.focal[1] * float64(h)
+}
+
+// Start starts the transition from the given "from" scene to the given "to" scene
+func (t *IrisTransition[T]) Start(fromScene, toScene Scene[T], sm SceneController[T]) {
+	t.BaseTransition.Start(fromScene, toScene, sm)
+	t.frameUpdated = false
+}
+
+// End releases the mask and the vertices of the shape and ends the transition
+func (t *IrisTransition[T]) End() {
+	t.mask.Dispose()
+	t.vertices, t.indices = nil, nil
+	t.BaseTransition.End()
+}
+
+// Update updates the transition state
+func (t *IrisTransition[T]) Update() error {
+	if !t.frameUpdated {
+		if t.Advance() {
+			t.End()
+		}
+		t.frameUpdated = true
+	}
+
+	// Update the scenes
+	return t.UpdateScenes()
+}
+
+// Draw draws the transition effect
+func (t *IrisTransition[T]) Draw(screen *ebiten.Image) {
+	toImg, fromImg := t.PreDraw(screen.Bounds())
+	w, h := screen.Bounds().Dx(), screen.Bounds().Dy()
+
+	inner, outer := toImg, fromImg
+	size := t.Ease(t.Progress())
+	if t.mode == IrisClose {
+		inner, outer = fromImg, toImg
+		size = 1 - size
+	}
+
+	// The radius that covers the farthest corner of the screen
+	x, y := t.FocalPoint(w, h)
+	radius := math.Max(
+		math.Max(math.Hypot(x, y), math.Hypot(float64(w)-x, y)),
+		math.Max(math.Hypot(x, float64(h)-y), math.Hypot(float64(w)-x, float64(h)-y)),
+	)
+
+	screen.DrawImage(outer, t.DrawOptions())
+	if size <= 0 {
+		t.frameUpdated = false
+		return
+	}
+
+	mask := t.mask.Image(w, h)
+
+	// Draw the shape and keep only the inner scene inside of it
+	path := &vector.Path{}
+	t.shape(path, float32(x), float32(y), float32(size*radius))
+	t.vertices, t.indices = path.AppendVerticesAndIndicesForFilling(t.vertices[:0], t.indices[:0])
+	for i := range t.vertices {
+		t.vertices[i].SrcX, t.vertices[i].SrcY = 1, 1
+		t.vertices[i].ColorR, t.vertices[i].ColorG, t.vertices[i].ColorB, t.vertices[i].ColorA = 1, 1, 1, 1
+	}
+	mask.DrawTriangles(t.vertices, t.indices, whiteSubImage, &t.trianglesOp)
+	op := t.DrawOptions()
+	op.Blend = ebiten.BlendSourceIn
+	mask.DrawImage(inner, op)
+
+	screen.DrawImage(mask, t.DrawOptions())
+	t.frameUpdated = false
+}
@@ -0,0 +1,94 @@
+package stagehand
+
+import (
+	"math"
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/stretchr/testify/assert"
+)
+
+type MockFocalScene struct {
+	MockScene
+}
+
+func (m *MockFocalScene) FocalPoint() (float64, float64) { return 10, 20 }
+
+func TestIrisTransition_Update(t *testing.T) {
+	from := &MockScene{}
+	to := &MockScene{}
+	trans := NewIrisTransition[int](CircleShape, IrisOpen, NewFactorTimeline(.5))
+	sm := NewSceneManager[int](from, 0)
+	sm.SwitchWithTransition(to, trans)
+
+	err := sm.Update()
+	assert.NoError(t, err)
+	assert.Equal(t, .5, trans.Progress())
+
+	err = sm.Update()
+	assert.NoError(t, err)
+	assert.Equal(t, .5, trans.Progress())
+
+	trans.Draw(ebiten.NewImage(100, 100))
+	assert.False(t, trans.frameUpdated)
+
+	err = sm.Update()
+	assert.NoError(t, err)
+	assert.Equal(t, to, sm.current)
+}
+
+func TestIrisTransition_FocalPoint(t *testing.T) {
+	trans := NewIrisTransition[int](CircleShape, IrisClose, NewFactorTimeline(.5))
+
+	trans.Start(&MockScene{}, &MockScene{}, nil)
+	x, y := trans.FocalPoint(100, 50)
+	assert.Equal(t, 50., x)
+	assert.Equal(t, 25., y)
+
+	// Tracked from the origin scene
+	trans.Start(&MockFocalScene{}, &MockScene{}, nil)
+	x, y = trans.FocalPoint(100, 50)
+	assert.Equal(t, 10., x)
+	assert.Equal(t, 20., y)
+
+	// Unless it was fixed
+	trans.SetFocalPoint(0, 1)
+	x, y = trans.FocalPoint(100, 50)
+	assert.Equal(t, 0., x)
+	assert.Equal(t, 50., y)
+}
+
+func TestIrisTransition_Draw(t *testing.T) {
+	shapes := []Shape{CircleShape, DiamondShape, StarShape(5, .5)}
+	modes := []IrisMode{IrisOpen, IrisClose}
+	for _, shape := range shapes {
+		for _, mode := range modes {
+			from := &MockScene{}
+			to := &MockScene{}
+			trans := NewIrisTransition[int](shape, mode, NewFactorTimeline(.5), WithEasing(EaseOutBack))
+			trans.Start(from, to, nil)
+			trans.Update()
+			trans.Draw(ebiten.NewImage(100, 100))
+			assert.True(t, from.drawCalled)
+			assert.True(t, to.drawCalled)
+
+			// The mask and the shape are released when the transition ends
+			trans.sm = NewSceneManager[int](from, 0)
+			trans.End()
+			assert.Nil(t, trans.mask.img)
+			assert.Nil(t, trans.vertices)
+		}
+	}
+}
+
+func TestPolygonShape_CoversRadius(t *testing.T) {
+	path := &vector.Path{}
+	DiamondShape(path, 0, 0, 1)
+	vs, _ := path.AppendVerticesAndIndicesForFilling(nil, nil)
+
+	// The diamond vertices are scaled so its edges touch the unit circle
+	for _, v := range vs {
+		assert.InDelta(t, math.Sqrt2, math.Hypot(float64(v.DstX), float64(v.DstY)), 1e-5)
+	}
+}
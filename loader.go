@@ -0,0 +1,158 @@
+package stagehand
+
+import (
+	"fmt"
+	"math"
+	"sync/atomic"
+)
+
+// An AsyncLoadable scene prepares its resources in a goroutine before it's loaded
+type AsyncLoadable[T any] interface {
+	Scene[T]
+	// Runs in a goroutine before Load, it must not touch the game state. The progress, from 0 to 1, is
+	// reported to the loading scene
+	Prepare(report func(progress float64)) error
+}
+
+// A LoadingScene is shown while an AsyncLoadable scene is prepared
+type LoadingScene[T any] interface {
+	Scene[T]
+	StartLoading(*Loader[T]) // Runs before the scene is loaded
+}
+
+// A Loader tracks the preparation of an AsyncLoadable scene
+type Loader[T any] struct {
+	scene    AsyncLoadable[T]
+	progress atomic.Uint64
+	err      error
+	done     chan struct{}
+}
+
+// startLoader prepares the scene in a goroutine, a panic is reported as an error
+func startLoader[T any](scene AsyncLoadable[T]) *Loader[T] {
+	l := &Loader[T]{scene: scene, done: make(chan struct{})}
+	go func() {
+		defer close(l.done)
+		defer func() {
+			if r := recover(); r != nil {
+				l.err = fmt.Errorf("stagehand: preparing scene panicked: %v", r)
+			}
+		}()
+		if err := l.scene.Prepare(l.report); err != nil {
+			l.err = fmt.Errorf("stagehand: preparing scene: %w", err)
+			return
+		}
+		l.report(1)
+	}()
+	return l
+}
+
+func (l *Loader[T]) report(progress float64) {
+	l.progress.Store(math.Float64bits(math.Max(0, math.Min(1, progress))))
+}
+
+// Progress returns the last progress reported by the scene
+func (l *Loader[T]) Progress() float64 {
+	return math.Float64frombits(l.progress.Load())
+}
+
+// Done reports whether the preparation finished, successfully or not
+func (l *Loader[T]) Done() bool {
+	select {
+	case <-l.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// Err returns the error of the preparation, it's only set once done
+func (l *Loader[T]) Err() error {
+	if !l.Done() {
+		return nil
+	}
+	return l.err
+}
+
+// Scene returns the scene being prepared
+func (l *Loader[T]) Scene() AsyncLoadable[T] {
+	return l.scene
+}
+
+// A loadingSwitch is a switch waiting for the preparation of its scene
+type loadingSwitch[T any] struct {
+	loader       *Loader[T]
+	loadingScene Scene[T]
+	transition   SceneTransition[T]
+}
+
+// SwitchWithLoader switches to the loading scene while the scene is prepared in a goroutine, then
+// switches to the scene with the optional transition on the game thread. The switch is dropped if
+// the loading scene is left before the preparation finishes, or if the interrupt policy drops the
+// switch to the loading scene. Scenes already prepared by the cache are switched to directly
+func (s *SceneManager[T]) SwitchWithLoader(scene AsyncLoadable[T], loadingScene Scene[T], transition SceneTransition[T]) *Loader[T] {
+	var loader *Loader[T]
+	if s.cache != nil {
+		loader = s.cache.Preload(scene)
+		if loader.Done() && loader.Err() == nil {
+			s.acceptSwitch(scene, transition, func() { s.loading = nil })
+			return loader
+		}
+	} else {
+		loader = startLoader(scene)
+	}
+
+	loading := &loadingSwitch[T]{loader: loader, loadingScene: loadingScene, transition: transition}
+	s.acceptSwitch(loadingScene, nil, func() {
+		s.loading = loading
+		if c, ok := loadingScene.(LoadingScene[T]); ok {
+			c.StartLoading(loader)
+		}
+	})
+	return loader
+}
+
+// acceptSwitch switches to the scene applying the interrupt policy, accepted runs right before the
+// switch so nothing is kept for a switch that is dropped
+func (s *SceneManager[T]) acceptSwitch(scene Scene[T], transition SceneTransition[T], accepted func()) {
+	origin, ok := s.interrupt(s.policy, func() { s.acceptSwitch(scene, transition, accepted) })
+	if ok {
+		accepted()
+		s.switchFrom(s.controller(), origin, scene, transition)
+	}
+}
+
+// SetLoadErrorHandler sets the function called with the errors of the preparations started by
+// SwitchWithLoader, without one the errors are returned by Update
+func (s *SceneManager[T]) SetLoadErrorHandler(handler func(error)) {
+	s.onLoadError = handler
+}
+
+// finishLoading switches to the prepared scene once the preparation finishes
+func (s *SceneManager[T]) finishLoading() error {
+	loading := s.loading
+	if loading == nil || !loading.loader.Done() {
+		return nil
+	}
+	if _, ok := s.current.(SceneTransition[T]); ok {
+		// Wait for the loading scene to be reached
+		return nil
+	}
+	s.loading = nil
+
+	if err := loading.loader.Err(); err != nil {
+		if s.cache != nil {
+			s.cache.Evict(loading.loader.scene)
+		}
+		if s.onLoadError != nil {
+			s.onLoadError(err)
+			return nil
+		}
+		return err
+	}
+	if s.current != ProtoScene[T](loading.loadingScene) {
+		return nil
+	}
+	s.SwitchWithTransition(loading.loader.scene, loading.transition)
+	return nil
+}
@@ -0,0 +1,177 @@
+package stagehand
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type MockAsyncScene struct {
+	MockScene
+	release chan error
+	started chan struct{}
+}
+
+func NewMockAsyncScene() *MockAsyncScene {
+	return &MockAsyncScene{release: make(chan error), started: make(chan struct{})}
+}
+
+func (m *MockAsyncScene) Prepare(report func(float64)) error {
+	report(.5)
+	close(m.started)
+	return <-m.release
+}
+
+type MockLoadingScene struct {
+	MockScene
+	loader *Loader[int]
+}
+
+func (m *MockLoadingScene) StartLoading(loader *Loader[int]) {
+	m.loader = loader
+}
+
+func TestSceneManager_SwitchWithLoader(t *testing.T) {
+	sm := NewSceneManager[int](&MockScene{}, 0)
+	dest := NewMockAsyncScene()
+	loading := &MockLoadingScene{}
+	trans := &baseTransitionImplementation{}
+
+	loader := sm.SwitchWithLoader(dest, loading, trans)
+	assert.Equal(t, loader, loading.loader)
+	assert.Equal(t, loading, sm.current)
+	assert.False(t, dest.loadCalled)
+
+	<-dest.started
+	assert.Equal(t, .5, loader.Progress())
+	assert.False(t, loader.Done())
+
+	// The loading scene is shown until the preparation finishes
+	assert.NoError(t, sm.Update())
+	assert.Equal(t, loading, sm.current)
+
+	dest.release <- nil
+	<-loader.done
+	assert.Equal(t, 1., loader.Progress())
+	assert.NoError(t, loader.Err())
+
+	assert.NoError(t, sm.Update())
+	assert.Equal(t, trans, sm.current)
+	assert.True(t, dest.loadCalled)
+
+	trans.End()
+	assert.Equal(t, dest, sm.current)
+}
+
+func TestSceneManager_SwitchWithLoaderError(t *testing.T) {
+	sm := NewSceneManager[int](&MockScene{}, 0)
+	dest := NewMockAsyncScene()
+	loading := &MockScene{}
+
+	loader := sm.SwitchWithLoader(dest, loading, nil)
+	dest.release <- errors.New("missing asset")
+	<-loader.done
+
+	err := sm.Update()
+	assert.ErrorContains(t, err, "missing asset")
+	assert.Equal(t, loading, sm.current)
+	assert.False(t, dest.loadCalled)
+}
+
+func TestSceneManager_SwitchWithLoaderErrorHandler(t *testing.T) {
+	sm := NewSceneManager[int](&MockScene{}, 0)
+	var handled error
+	sm.SetLoadErrorHandler(func(err error) { handled = err })
+
+	dest := &panickingScene{}
+	loader := sm.SwitchWithLoader(dest, &MockScene{}, nil)
+	<-loader.done
+
+	assert.NoError(t, sm.Update())
+	assert.ErrorContains(t, handled, "boom")
+	assert.Equal(t, handled, loader.Err())
+}
+
+type panickingScene struct {
+	MockScene
+}
+
+func (m *panickingScene) Prepare(report func(float64)) error {
+	panic("boom")
+}
+
+func TestSceneManager_SwitchWithLoaderLeft(t *testing.T) {
+	sm := NewSceneManager[int](&MockScene{}, 0)
+	dest := NewMockAsyncScene()
+	loader := sm.SwitchWithLoader(dest, &MockScene{}, nil)
+
+	// Leaving the loading scene drops the switch
+	other := &MockScene{}
+	sm.SwitchTo(other)
+	dest.release <- nil
+	<-loader.done
+
+	assert.NoError(t, sm.Update())
+	assert.Equal(t, other, sm.current)
+	assert.False(t, dest.loadCalled)
+}
+
+func TestSceneManager_SwitchWithLoaderIgnored(t *testing.T) {
+	sm := NewSceneManager[int](&MockScene{}, 0)
+	sm.SetInterruptPolicy(InterruptIgnore)
+	trans := &baseTransitionImplementation{}
+	dest := &MockScene{}
+	sm.SwitchWithTransition(dest, trans)
+
+	// The switch is dropped, so its preparation never fires
+	async := NewMockAsyncScene()
+	loading := &MockLoadingScene{}
+	loader := sm.SwitchWithLoader(async, loading, nil)
+	assert.Nil(t, sm.loading)
+	assert.Nil(t, loading.loader)
+
+	async.release <- errors.New("missing asset")
+	<-loader.done
+	trans.End()
+	assert.NoError(t, sm.Update())
+	assert.Equal(t, dest, sm.current)
+}
+
+func TestSceneManager_SwitchWithLoaderQueued(t *testing.T) {
+	sm := NewSceneManager[int](&MockScene{}, 0)
+	sm.SetInterruptPolicy(InterruptQueue)
+	trans := &baseTransitionImplementation{}
+	sm.SwitchWithTransition(&MockScene{}, trans)
+
+	async := NewMockAsyncScene()
+	loading := &MockLoadingScene{}
+	loader := sm.SwitchWithLoader(async, loading, nil)
+	assert.Nil(t, sm.loading)
+
+	// The loading scene is reached once the transition finishes
+	trans.End()
+	assert.Equal(t, loading, sm.current)
+	assert.Equal(t, loader, loading.loader)
+
+	async.release <- nil
+	<-loader.done
+	assert.NoError(t, sm.Update())
+	assert.Equal(t, async, sm.current)
+}
+
+func TestSceneDirector_SwitchWithLoader(t *testing.T) {
+	menu := &MockScene{}
+	director := NewSceneDirector[int](menu, 0, nil)
+	dest := NewMockAsyncScene()
+	loading := &MockLoadingScene{}
+
+	// Both the loading scene and the prepared scene get the director
+	loader := director.SwitchWithLoader(dest, loading, nil)
+	assert.Same(t, director, loading.sm)
+	dest.release <- nil
+	<-loader.done
+	assert.NoError(t, director.Update())
+	assert.Same(t, dest, director.current)
+	assert.Same(t, director, dest.sm)
+}
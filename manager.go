@@ -3,53 +3,190 @@ package stagehand
 import ebiten "github.com/hajimehoshi/ebiten/v2"
 
 type SceneManager[T any] struct {
-	current ProtoScene[T]
+	current     ProtoScene[T]
+	policy      InterruptPolicy
+	pending     []func() // switches queued until the running transition finishes
+	reverting   bool     // whether the running transition is playing back to its origin
+	width       int      // size of the last layout
+	height      int
+	loading     *loadingSwitch[T]
+	onLoadError func(error)
+	cache       *SceneCache[T]
+	registry    *Registry[T]
+	state       T                  // state the current scene was loaded with
+	arriving    func(T) T          // merges a payload into the states given to the scene being switched to
+	owner       SceneController[T] // controller given to the scenes, the director embedding the manager if any
+	snapshots   [2]RenderTarget    // last frames of the transitions interrupted by InterruptChain
+	chained     *RenderTarget      // snapshot the running transition started from
 }
 
 func NewSceneManager[T any](scene Scene[T], state T) *SceneManager[T] {
 	s := &SceneManager[T]{current: scene}
-	scene.Load(state, s)
+	s.load(scene, state, s)
 	return s
 }
 
-// Scene Switching
-func (s *SceneManager[T]) SwitchTo(scene Scene[T]) {
-	if prevTransition, ok := s.current.(SceneTransition[T]); ok {
-		// previous transition is still running, end it first
-		prevTransition.End()
+// controller returns the controller given to the scenes, so scenes of a director can process triggers
+// whichever method switched to them
+func (s *SceneManager[T]) controller() SceneController[T] {
+	if s.owner != nil {
+		return s.owner
 	}
-	if c, ok := s.current.(Scene[T]); ok {
-		scene.Load(c.Unload(), s)
-		s.current = scene
+	return s
+}
+
+// load loads the scene with the state, keeping it for State
+func (s *SceneManager[T]) load(scene Scene[T], state T, sm SceneController[T]) {
+	s.state = s.arrive(state)
+	scene.Load(s.state, sm)
+}
+
+// arrive returns the state given to the scene being switched to
+func (s *SceneManager[T]) arrive(state T) T {
+	if s.arriving != nil {
+		return s.arriving(state)
+	}
+	return state
+}
+
+// State returns the state of the current scene without unloading it. It's read from scenes that
+// implement StatefulScene, otherwise it's the state the scene was loaded with. While a transition is
+// running it's the state of the destination
+func (s *SceneManager[T]) State() T {
+	if scene, ok := s.current.(Scene[T]); ok {
+		return s.peek(scene)
+	}
+	return s.state
+}
+
+// peek returns the state of the scene, scene must be the current one
+func (s *SceneManager[T]) peek(scene Scene[T]) T {
+	if c, ok := unwrapScene(scene).(StatefulScene[T]); ok {
+		return c.State()
+	}
+	return s.state
+}
+
+// SetInterruptPolicy sets what happens when a switch is requested while a transition is running
+func (s *SceneManager[T]) SetInterruptPolicy(policy InterruptPolicy) {
+	s.policy = policy
+}
+
+func (s *SceneManager[T]) InterruptPolicy() InterruptPolicy {
+	return s.policy
+}
+
+// SetCache sets the cache that keeps the prepared scenes warm
+func (s *SceneManager[T]) SetCache(cache *SceneCache[T]) {
+	s.cache = cache
+	if scene, ok := s.current.(Scene[T]); ok {
+		cache.setActive(scene, true)
 	}
 }
 
+func (s *SceneManager[T]) Cache() *SceneCache[T] {
+	return s.cache
+}
+
+// Preload starts the preparation of the scene ahead of time, e.g. the next level while the current one
+// plays. A cache with the default capacity is created if none is set
+func (s *SceneManager[T]) Preload(scene AsyncLoadable[T]) *Loader[T] {
+	if s.cache == nil {
+		s.SetCache(NewSceneCache[T](DefaultCacheCapacity))
+	}
+	return s.cache.Preload(scene)
+}
+
+// setActive tells the cache whether the scene is in use
+func (s *SceneManager[T]) setActive(scene Scene[T], active bool) {
+	if s.cache != nil {
+		s.cache.setActive(unwrapScene(scene), active)
+	}
+}
+
+// Scene Switching
+func (s *SceneManager[T]) SwitchTo(scene Scene[T]) {
+	s.SwitchWithPolicy(scene, nil, s.policy)
+}
+
 func (s *SceneManager[T]) SwitchWithTransition(scene Scene[T], transition SceneTransition[T]) {
-	if prevTransition, ok := s.current.(SceneTransition[T]); ok {
-		// previous transition is still running, end it to start the next transition
-		prevTransition.End()
-	}
-	sc := s.current.(Scene[T])
-	transition.Start(sc, scene, s)
-	if c, ok := sc.(TransitionAwareScene[T]); ok {
-		scene.Load(c.PreTransition(scene), s)
+	s.SwitchWithPolicy(scene, transition, s.policy)
+}
+
+// SwitchWithPolicy switches to the scene, with an optional transition, applying the policy instead of
+// the one of the manager if a transition is running
+func (s *SceneManager[T]) SwitchWithPolicy(scene Scene[T], transition SceneTransition[T], policy InterruptPolicy) {
+	origin, ok := s.interrupt(policy, func() { s.SwitchWithPolicy(scene, transition, policy) })
+	if ok {
+		s.switchFrom(s.controller(), origin, scene, transition)
+	}
+}
+
+// switchFrom switches from the origin to the scene, sm is the controller given to the scenes
+func (s *SceneManager[T]) switchFrom(sm SceneController[T], origin, scene Scene[T], transition SceneTransition[T]) {
+	s.setActive(scene, true)
+	if transition == nil {
+		s.releaseSnapshot()
+		s.load(scene, unwrapScene(origin).Unload(), sm)
+		s.arriving = nil
+		s.current = scene
+		if unwrapScene(origin) != scene {
+			s.setActive(origin, false)
+		}
+		return
+	}
+	transition.Start(origin, scene, sm)
+	if c, ok := unwrapScene(origin).(TransitionAwareScene[T]); ok {
+		s.load(scene, c.PreTransition(scene), sm)
 	} else {
-		scene.Load(sc.Unload(), s)
+		s.load(scene, origin.Unload(), sm)
 	}
 	s.current = transition
 }
 
 func (s *SceneManager[T]) ReturnFromTransition(scene, origin Scene[T]) {
+	s.returnFromTransition(s.controller(), scene, origin)
+}
+
+// returnFromTransition finishes the running transition and runs the queued switches, sm is the
+// controller given to the scenes
+func (s *SceneManager[T]) returnFromTransition(sm SceneController[T], scene, origin Scene[T]) {
+	s.releaseSnapshot()
+	if s.reverting {
+		// The transition played back to its origin
+		s.reverting = false
+		s.arriving = nil
+		scene, origin = origin, scene
+	}
+	scene, origin = unwrapScene(scene), unwrapScene(origin)
 	if c, ok := scene.(TransitionAwareScene[T]); ok {
-		c.PostTransition(origin.Unload(), origin)
+		s.state = s.arrive(origin.Unload())
+		c.PostTransition(s.state, origin)
 	} else {
-		scene.Load(origin.Unload(), s)
+		s.load(scene, origin.Unload(), sm)
 	}
+	s.arriving = nil
 	s.current = scene
+	s.setActive(origin, false)
+
+	for len(s.pending) > 0 {
+		if _, ok := s.current.(SceneTransition[T]); ok {
+			break
+		}
+		next := s.pending[0]
+		s.pending = s.pending[1:]
+		next()
+	}
 }
 
 // Ebiten Interface
 func (s *SceneManager[T]) Update() error {
+	if s.cache != nil {
+		s.cache.update()
+	}
+	if err := s.finishLoading(); err != nil {
+		return err
+	}
 	return s.current.Update()
 }
 
@@ -58,5 +195,6 @@ func (s *SceneManager[T]) Draw(screen *ebiten.Image) {
 }
 
 func (s *SceneManager[T]) Layout(w, h int) (int, int) {
-	return s.current.Layout(w, h)
+	s.width, s.height = s.current.Layout(w, h)
+	return s.width, s.height
 }
@@ -0,0 +1,170 @@
+package stagehand
+
+import (
+	_ "embed"
+	"image"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+//go:embed shaders/mask.kage
+var maskShader []byte
+
+// A MaskGenerator returns a grayscale mask for the given screen size
+type MaskGenerator func(w, h int) *ebiten.Image
+
+// A MaskTransition is a gradient wipe, it reveals the destination scene where the luminance of a
+// grayscale mask is below the progress of the transition
+type MaskTransition[T any] struct {
+	BaseTransition[T]
+	shader       *ebiten.Shader
+	generator    MaskGenerator
+	mask         *ebiten.Image // mask with the size of the screen
+	stretched    RenderTarget  // copy of the mask stretched to the screen size, if needed
+	shaderOp     ebiten.DrawRectShaderOptions
+	softness     float64
+	inverted     bool
+	frameUpdated bool
+}
+
+// NewMaskTransition returns a wipe following the mask image, it's stretched to the screen size
+func NewMaskTransition[T any](mask *ebiten.Image, timeline *Timeline, opts ...TransitionOption) *MaskTransition[T] {
+	return NewGeneratedMaskTransition[T](func(w, h int) *ebiten.Image { return mask }, timeline, opts...)
+}
+
+// NewGeneratedMaskTransition returns a wipe following the masks created by the generator, it runs
+// again every time the screen size changes
+func NewGeneratedMaskTransition[T any](generator MaskGenerator, timeline *Timeline, opts ...TransitionOption) *MaskTransition[T] {
+	shader, err := compileShader(maskShader)
+	if err != nil {
+		panic(err)
+	}
+	t := &MaskTransition[T]{
+		shader:    shader,
+		generator: generator,
+		softness:  .1,
+	}
+	t.SetTimeline(timeline)
+	t.SetOptions(opts...)
+	return t
+}
+
+// SetSoftness sets the width of the revealing edge in luminance, from 0 for a hard edge to 1
+func (t *MaskTransition[T]) SetSoftness(softness float64) *MaskTransition[T] {
+	t.softness = softness
+	return t
+}
+
+// SetInverted makes the transition reveal the bright areas of the mask first
+func (t *MaskTransition[T]) SetInverted(inverted bool) *MaskTransition[T] {
+	t.inverted = inverted
+	return t
+}
+
+// Start starts the transition from the given "from" scene to the given "to" scene
+func (t *MaskTransition[T]) Start(fromScene, toScene Scene[T], sm SceneController[T]) {
+	t.BaseTransition.Start(fromScene, toScene, sm)
+	t.frameUpdated = false
+}
+
+// Update updates the transition state
+func (t *MaskTransition[T]) Update() error {
+	if !t.frameUpdated {
+		if t.Advance() {
+			t.End()
+		}
+		t.frameUpdated = true
+	}
+
+	// Update the scenes
+	return t.UpdateScenes()
+}
+
+// Draw draws the transition effect
+func (t *MaskTransition[T]) Draw(screen *ebiten.Image) {
+	toImg, fromImg := t.PreDraw(screen.Bounds())
+	w, h := screen.Bounds().Dx(), screen.Bounds().Dy()
+
+	invert := float32(0)
+	if t.inverted {
+		invert = 1
+	}
+	if t.shaderOp.Uniforms == nil {
+		t.shaderOp.Uniforms = make(map[string]any, 3)
+	}
+	t.shaderOp.Uniforms["Progress"] = float32(t.Ease(t.Progress()))
+	t.shaderOp.Uniforms["Softness"] = float32(t.softness)
+	t.shaderOp.Uniforms["Invert"] = invert
+	t.shaderOp.Images = [4]*ebiten.Image{fromImg, toImg, t.maskFor(w, h)}
+	screen.DrawRectShader(w, h, t.shader, &t.shaderOp)
+	t.frameUpdated = false
+}
+
+// End releases the stretched mask and ends the transition
+func (t *MaskTransition[T]) End() {
+	t.stretched.Dispose()
+	t.mask = nil
+	t.shaderOp.Images = [4]*ebiten.Image{}
+	t.BaseTransition.End()
+}
+
+// maskFor returns the mask stretched to the given size, it's only rebuilt when the size changes
+func (t *MaskTransition[T]) maskFor(w, h int) *ebiten.Image {
+	if t.mask != nil && t.mask.Bounds().Dx() == w && t.mask.Bounds().Dy() == h {
+		return t.mask
+	}
+	src := t.generator(w, h)
+	if src.Bounds().Dx() == w && src.Bounds().Dy() == h {
+		t.mask = src
+		return t.mask
+	}
+
+	t.mask = t.stretched.Image(w, h)
+	op := t.DrawOptions()
+	op.Filter = ebiten.FilterLinear
+	op.GeoM.Scale(float64(w)/float64(src.Bounds().Dx()), float64(h)/float64(src.Bounds().Dy()))
+	t.mask.DrawImage(src, op)
+	return t.mask
+}
+
+// LinearGradientMask generates a gradient mask that wipes along the angle, in radians, 0 wipes from
+// left to right
+func LinearGradientMask(angle float64) MaskGenerator {
+	dx, dy := math.Cos(angle), math.Sin(angle)
+	return func(w, h int) *ebiten.Image {
+		// Project the corners to normalize the gradient
+		lo, hi := math.Inf(1), math.Inf(-1)
+		for _, c := range [][2]float64{{0, 0}, {float64(w), 0}, {0, float64(h)}, {float64(w), float64(h)}} {
+			p := c[0]*dx + c[1]*dy
+			lo, hi = math.Min(lo, p), math.Max(hi, p)
+		}
+		return grayMask(w, h, func(x, y float64) float64 {
+			return (x*dx + y*dy - lo) / (hi - lo)
+		})
+	}
+}
+
+// RadialGradientMask generates a gradient mask that wipes from the center to the corners
+func RadialGradientMask() MaskGenerator {
+	return func(w, h int) *ebiten.Image {
+		cx, cy := float64(w)/2, float64(h)/2
+		radius := math.Hypot(cx, cy)
+		return grayMask(w, h, func(x, y float64) float64 {
+			return math.Hypot(x-cx, y-cy) / radius
+		})
+	}
+}
+
+// grayMask builds a mask from the luminance, from 0 to 1, of each pixel
+func grayMask(w, h int, luma func(x, y float64) float64) *ebiten.Image {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			v := uint8(math.Max(0, math.Min(1, luma(float64(x)+.5, float64(y)+.5))) * 255)
+			i := img.PixOffset(x, y)
+			img.Pix[i], img.Pix[i+1], img.Pix[i+2], img.Pix[i+3] = v, v, v, 255
+		}
+	}
+	return ebiten.NewImageFromImage(img)
+}
@@ -0,0 +1,79 @@
+package stagehand
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaskTransition_Update(t *testing.T) {
+	from := &MockScene{}
+	to := &MockScene{}
+	trans := NewMaskTransition[int](ebiten.NewImage(10, 10), NewFactorTimeline(.5))
+	sm := NewSceneManager[int](from, 0)
+	sm.SwitchWithTransition(to, trans)
+
+	err := sm.Update()
+	assert.NoError(t, err)
+	assert.Equal(t, .5, trans.Progress())
+
+	err = sm.Update()
+	assert.NoError(t, err)
+	assert.Equal(t, .5, trans.Progress())
+
+	trans.Draw(ebiten.NewImage(100, 100))
+	assert.False(t, trans.frameUpdated)
+	assert.Same(t, trans.stretched.img, trans.mask)
+
+	// The stretched mask is released when the transition ends
+	err = sm.Update()
+	assert.NoError(t, err)
+	assert.Equal(t, to, sm.current)
+	assert.Nil(t, trans.stretched.img)
+	assert.Nil(t, trans.mask)
+}
+
+func TestMaskTransition_Options(t *testing.T) {
+	trans := NewMaskTransition[int](ebiten.NewImage(10, 10), NewFactorTimeline(.5)).
+		SetSoftness(.3).
+		SetInverted(true)
+
+	assert.Equal(t, .3, trans.softness)
+	assert.True(t, trans.inverted)
+}
+
+func TestMaskTransition_MaskSize(t *testing.T) {
+	calls := 0
+	trans := NewGeneratedMaskTransition[int](func(w, h int) *ebiten.Image {
+		calls++
+		return ebiten.NewImage(10, 10)
+	}, NewFactorTimeline(.5))
+
+	// The mask is stretched to the screen
+	mask := trans.maskFor(100, 50)
+	assert.Equal(t, 100, mask.Bounds().Dx())
+	assert.Equal(t, 50, mask.Bounds().Dy())
+
+	// And only regenerated when the size changes
+	trans.maskFor(100, 50)
+	assert.Equal(t, 1, calls)
+	trans.maskFor(200, 50)
+	assert.Equal(t, 2, calls)
+}
+
+func TestMaskTransition_Generators(t *testing.T) {
+	from := &MockScene{}
+	to := &MockScene{}
+	generators := []MaskGenerator{LinearGradientMask(0), LinearGradientMask(1), RadialGradientMask()}
+	for _, generator := range generators {
+		mask := generator(20, 10)
+		assert.Equal(t, 20, mask.Bounds().Dx())
+		assert.Equal(t, 10, mask.Bounds().Dy())
+
+		trans := NewGeneratedMaskTransition[int](generator, NewFactorTimeline(.5), WithEasing(EaseInCubic))
+		trans.Start(from, to, nil)
+		trans.Update()
+		trans.Draw(ebiten.NewImage(20, 10))
+	}
+}
@@ -0,0 +1,111 @@
+package stagehand
+
+// A ModalScene is a Scene that completes with a result of type R
+type ModalScene[T, R any] interface {
+	Scene[T]
+	OpenModal(*Modal[T, R]) // Runs before the scene is loaded, must keep the modal to close it
+}
+
+// A Modal is a handle to a scene pushed with PushModal, it works as a future for the result
+type Modal[T, R any] struct {
+	stack     *SceneStack[T]
+	caller    Scene[T]
+	scene     Scene[T]
+	result    R
+	completed bool // whether the modal was closed with a result
+	closed    bool // whether the modal was closed or dismissed
+	done      bool // whether the modal scene left the stack
+	callback  func(R)
+}
+
+// PushModal pushes a scene over the top scene of the stack, the callback, if any, receives the result
+// once the modal scene is gone
+func PushModal[T, R any](s *SceneStack[T], scene ModalScene[T, R], callback func(R)) *Modal[T, R] {
+	m := newModal(s, scene, callback)
+	s.Push(scene)
+	return m
+}
+
+func PushModalWithTransition[T, R any](s *SceneStack[T], scene ModalScene[T, R], transition SceneTransition[T], callback func(R)) *Modal[T, R] {
+	m := newModal(s, scene, callback)
+	s.PushWithTransition(scene, transition)
+	return m
+}
+
+func newModal[T, R any](s *SceneStack[T], scene ModalScene[T, R], callback func(R)) *Modal[T, R] {
+	m := &Modal[T, R]{stack: s, caller: s.Top(), scene: scene, callback: callback}
+	s.watch(scene, m.finish)
+	scene.OpenModal(m)
+	return m
+}
+
+// Close completes the modal with the given result and returns to the calling scene. Nothing happens
+// if the modal was already closed or dismissed
+func (m *Modal[T, R]) Close(result R) {
+	if m.close() {
+		m.complete(result)
+		m.stack.PopTo(m.caller)
+	}
+}
+
+func (m *Modal[T, R]) CloseWithTransition(result R, transition SceneTransition[T]) {
+	if m.close() {
+		m.complete(result)
+		m.stack.PopToWithTransition(m.caller, transition)
+	}
+}
+
+// Dismiss returns to the calling scene without a result. Nothing happens if the modal was already
+// closed or dismissed
+func (m *Modal[T, R]) Dismiss() {
+	if m.close() {
+		m.stack.PopTo(m.caller)
+	}
+}
+
+func (m *Modal[T, R]) DismissWithTransition(transition SceneTransition[T]) {
+	if m.close() {
+		m.stack.PopToWithTransition(m.caller, transition)
+	}
+}
+
+// Done reports whether the modal scene is gone, including its closing transition
+func (m *Modal[T, R]) Done() bool {
+	return m.done
+}
+
+// Result returns the result of the modal, it's only available once the modal is done and was not dismissed
+func (m *Modal[T, R]) Result() (R, bool) {
+	if !m.done || !m.completed {
+		var zero R
+		return zero, false
+	}
+	return m.result, true
+}
+
+// Scene returns the modal scene, useful to identify the origin on PostTransition
+func (m *Modal[T, R]) Scene() Scene[T] {
+	return m.scene
+}
+
+// close reports whether the modal can still leave the stack, it only leaves once
+func (m *Modal[T, R]) close() bool {
+	if m.closed || m.done {
+		return false
+	}
+	m.closed = true
+	return true
+}
+
+func (m *Modal[T, R]) complete(result R) {
+	m.result = result
+	m.completed = true
+}
+
+// finish runs when the modal scene leaves the stack, before the calling scene is notified
+func (m *Modal[T, R]) finish() {
+	m.done = true
+	if m.completed && m.callback != nil {
+		m.callback(m.result)
+	}
+}
@@ -0,0 +1,145 @@
+package stagehand
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type MockModalScene struct {
+	MockScene
+	modal *Modal[int, string]
+}
+
+func (m *MockModalScene) OpenModal(modal *Modal[int, string]) {
+	m.modal = modal
+}
+
+type MockModalCaller struct {
+	MockTransitionAwareScene
+	modal      *Modal[int, string]
+	resultSeen string
+}
+
+func (m *MockModalCaller) PostTransition(state int, fromScene Scene[int]) {
+	m.MockTransitionAwareScene.PostTransition(state, fromScene)
+	if fromScene == m.modal.Scene() {
+		m.resultSeen, _ = m.modal.Result()
+	}
+}
+
+func TestModal_Close(t *testing.T) {
+	caller := &MockTransitionAwareScene{}
+	modalScene := &MockModalScene{}
+	ss := NewSceneStack[int](caller, 0)
+
+	var received string
+	modal := PushModal[int, string](ss, modalScene, func(r string) { received = r })
+	assert.Equal(t, modal, modalScene.modal)
+	assert.Equal(t, modalScene, ss.Top())
+	assert.False(t, modal.Done())
+
+	_, ok := modal.Result()
+	assert.False(t, ok)
+
+	modalScene.modal.Close("yes")
+	assert.True(t, modal.Done())
+	assert.Equal(t, caller, ss.Top())
+	assert.Equal(t, "yes", received)
+	assert.True(t, caller.postTransitionCalled)
+
+	result, ok := modal.Result()
+	assert.True(t, ok)
+	assert.Equal(t, "yes", result)
+}
+
+func TestModal_Dismiss(t *testing.T) {
+	caller := &MockScene{}
+	modalScene := &MockModalScene{}
+	ss := NewSceneStack[int](caller, 0)
+
+	called := false
+	modal := PushModal[int, string](ss, modalScene, func(r string) { called = true })
+
+	modal.Dismiss()
+	assert.True(t, modal.Done())
+	assert.False(t, called)
+	assert.Equal(t, caller, ss.Top())
+
+	_, ok := modal.Result()
+	assert.False(t, ok)
+}
+
+func TestModal_CloseWithTransition(t *testing.T) {
+	caller := &MockModalCaller{}
+	modalScene := &MockModalScene{}
+	trans := &baseTransitionImplementation{}
+	ss := NewSceneStack[int](caller, 0)
+
+	caller.modal = PushModal[int, string](ss, modalScene, nil)
+	caller.modal.CloseWithTransition("ok", trans)
+
+	// The result is only delivered once the modal is fully gone
+	assert.False(t, caller.modal.Done())
+	_, ok := caller.modal.Result()
+	assert.False(t, ok)
+
+	trans.End()
+	assert.True(t, caller.modal.Done())
+	assert.Equal(t, "ok", caller.resultSeen)
+}
+
+func TestModal_PopToDiscardsNestedScenes(t *testing.T) {
+	caller := &MockScene{}
+	modalScene := &MockModalScene{}
+	nested := &MockScene{}
+	ss := NewSceneStack[int](caller, 0)
+
+	modal := PushModalWithTransition[int, string](ss, modalScene, &baseTransitionImplementation{}, nil)
+	ss.Push(nested)
+
+	modal.Close("done")
+	assert.Equal(t, caller, ss.Top())
+	assert.Equal(t, 1, ss.Len())
+	assert.True(t, nested.unloadCalled)
+	assert.True(t, modal.Done())
+}
+
+func TestModal_CloseTwice(t *testing.T) {
+	caller := &MockScene{}
+	modalScene := &MockModalScene{}
+	ss := NewSceneStack[int](caller, 0)
+
+	var received []string
+	modal := PushModal[int, string](ss, modalScene, func(r string) { received = append(received, r) })
+	modal.Close("yes")
+
+	// Later calls don't pop the scenes pushed over the caller since
+	other := &MockScene{}
+	ss.Push(other)
+	modal.Close("no")
+	modal.Dismiss()
+	assert.Equal(t, other, ss.Top())
+	assert.Equal(t, 2, ss.Len())
+	assert.Equal(t, []string{"yes"}, received)
+	result, _ := modal.Result()
+	assert.Equal(t, "yes", result)
+}
+
+func TestModal_CloseAfterDismiss(t *testing.T) {
+	caller := &MockScene{}
+	modalScene := &MockModalScene{}
+	trans := &baseTransitionImplementation{}
+	ss := NewSceneStack[int](caller, 0)
+
+	called := false
+	modal := PushModal[int, string](ss, modalScene, func(r string) { called = true })
+	modal.DismissWithTransition(trans)
+	modal.Close("late")
+	trans.End()
+
+	assert.True(t, modal.Done())
+	assert.False(t, called)
+	_, ok := modal.Result()
+	assert.False(t, ok)
+}
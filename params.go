@@ -0,0 +1,369 @@
+package stagehand
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joelschutz/stagehand/ruleset"
+)
+
+var (
+	ErrUnknownTransition = ruleset.ErrUnknownTransition
+	ErrInvalidParam      = errors.New("stagehand: invalid transition parameter")
+)
+
+// TransitionParams are the decoded parameters of a transition, the "type" key names its constructor,
+// e.g. {"type": "fade", "duration": "500ms", "easing": "outCubic"}
+type TransitionParams map[string]any
+
+// A TransitionFactory builds a transition from its parameters
+type TransitionFactory[T any] func(params TransitionParams) (SceneTransition[T], error)
+
+// Type returns the name of the constructor of the transition
+func (p TransitionParams) Type() string {
+	s, _ := p["type"].(string)
+	return s
+}
+
+// Has reports whether the parameter is set
+func (p TransitionParams) Has(key string) bool {
+	_, ok := p[key]
+	return ok
+}
+
+// String returns the parameter as a string, or the fallback if it's not set
+func (p TransitionParams) String(key, fallback string) (string, error) {
+	v, ok := p[key]
+	if !ok {
+		return fallback, nil
+	}
+	s, ok := v.(string)
+	if !ok {
+		return "", p.invalid(key)
+	}
+	return s, nil
+}
+
+// Float returns the parameter as a number, or the fallback if it's not set
+func (p TransitionParams) Float(key string, fallback float64) (float64, error) {
+	v, ok := p[key]
+	if !ok {
+		return fallback, nil
+	}
+	f, ok := toFloat(v)
+	if !ok {
+		return 0, p.invalid(key)
+	}
+	return f, nil
+}
+
+// Bool returns the parameter as a boolean, or the fallback if it's not set
+func (p TransitionParams) Bool(key string, fallback bool) (bool, error) {
+	v, ok := p[key]
+	if !ok {
+		return fallback, nil
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return false, p.invalid(key)
+	}
+	return b, nil
+}
+
+// Duration returns the parameter as a duration written like "500ms" or "1.5s", or the fallback if
+// it's not set
+func (p TransitionParams) Duration(key string, fallback time.Duration) (time.Duration, error) {
+	s, err := p.String(key, "")
+	if err != nil || s == "" {
+		return fallback, err
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, p.invalid(key)
+	}
+	return d, nil
+}
+
+// Color returns the parameter as a color written like "#rrggbb" or "#rrggbbaa", or the fallback if
+// it's not set
+func (p TransitionParams) Color(key string, fallback color.Color) (color.Color, error) {
+	s, err := p.String(key, "")
+	if err != nil || s == "" {
+		return fallback, err
+	}
+	v, err := strconv.ParseUint(strings.TrimPrefix(s, "#"), 16, 32)
+	if err != nil || s[0] != '#' || (len(s) != 7 && len(s) != 9) {
+		return nil, p.invalid(key)
+	}
+	if len(s) == 7 {
+		v = v<<8 | 0xff
+	}
+	return color.NRGBA{R: uint8(v >> 24), G: uint8(v >> 16), B: uint8(v >> 8), A: uint8(v)}, nil
+}
+
+// Timeline returns the timeline set by the "duration", "ticks" or "factor" parameter
+func (p TransitionParams) Timeline() (*Timeline, error) {
+	switch {
+	case p.Has("duration"):
+		d, err := p.Duration("duration", 0)
+		return NewDurationTimeline(d), err
+	case p.Has("ticks"):
+		ticks, err := p.Float("ticks", 0)
+		return NewTicksTimeline(int(ticks)), err
+	case p.Has("factor"):
+		factor, err := p.Float("factor", 0)
+		return NewFactorTimeline(factor), err
+	}
+	return nil, fmt.Errorf("%w: a duration, ticks or factor is required", ErrInvalidParam)
+}
+
+// Options returns the options set by the "easing" parameter, named as in EasingByName
+func (p TransitionParams) Options() ([]TransitionOption, error) {
+	name, err := p.String("easing", "")
+	if err != nil || name == "" {
+		return nil, err
+	}
+	easing, ok := EasingByName(name)
+	if !ok {
+		return nil, p.invalid("easing")
+	}
+	return []TransitionOption{WithEasing(easing)}, nil
+}
+
+// choice returns the value mapped to the parameter, or the fallback if it's not set
+func choice[V any](p TransitionParams, key string, fallback V, choices map[string]V) (V, error) {
+	s, err := p.String(key, "")
+	if err != nil || s == "" {
+		return fallback, err
+	}
+	v, ok := choices[ruleset.NormalizeName(s)]
+	if !ok {
+		return fallback, p.invalid(key)
+	}
+	return v, nil
+}
+
+func (p TransitionParams) invalid(key string) error {
+	return fmt.Errorf("%w %q: %v", ErrInvalidParam, key, p[key])
+}
+
+func toFloat(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	}
+	return 0, false
+}
+
+// builtinTransitions returns the factories of the built-in transitions, named as in
+// ruleset.TransitionTypes. Every factory accepts the timeline and easing parameters
+func builtinTransitions[T any]() map[string]TransitionFactory[T] {
+	return map[string]TransitionFactory[T]{
+		"fade":       fadeFactory[T],
+		"crossfade":  crossfadeFactory[T],
+		"slide":      slideFactory[T],
+		"dissolve":   shaderFactory(NewDissolveTransition[T]),
+		"radialwipe": shaderFactory(NewRadialWipeTransition[T]),
+		"ripple":     shaderFactory(NewRippleTransition[T]),
+		"pixelsort":  shaderFactory(NewPixelSortTransition[T]),
+		"linearmask": maskFactory[T](false),
+		"radialmask": maskFactory[T](true),
+		"iris":       irisFactory[T],
+	}
+}
+
+// fadeFactory accepts a "color", it fades through black by default. The "out", "hold" and "in"
+// durations replace the timeline
+func fadeFactory[T any](p TransitionParams) (SceneTransition[T], error) {
+	opts, err := p.Options()
+	if err != nil {
+		return nil, err
+	}
+	c, err := p.Color("color", color.Black)
+	if err != nil {
+		return nil, err
+	}
+	if p.Has("out") || p.Has("hold") || p.Has("in") {
+		var phases [3]time.Duration
+		for i, key := range []string{"out", "hold", "in"} {
+			if phases[i], err = p.Duration(key, 0); err != nil {
+				return nil, err
+			}
+		}
+		return NewFadeColorTransition[T](c, phases[0], phases[1], phases[2], opts...), nil
+	}
+	timeline, err := p.Timeline()
+	if err != nil {
+		return nil, err
+	}
+	return NewTimelineFadeTransition[T](timeline, opts...).SetColor(c), nil
+}
+
+func crossfadeFactory[T any](p TransitionParams) (SceneTransition[T], error) {
+	timeline, opts, err := timelineAndOptions(p)
+	if err != nil {
+		return nil, err
+	}
+	return NewCrossfadeTransition[T](timeline, opts...), nil
+}
+
+// slideFactory accepts a "direction" or an "angle" in degrees, a "mode" and a "parallax" speed
+func slideFactory[T any](p TransitionParams) (SceneTransition[T], error) {
+	timeline, opts, err := timelineAndOptions(p)
+	if err != nil {
+		return nil, err
+	}
+	direction, err := choice(p, "direction", LeftToRight, map[string]SlideDirection{
+		"lefttoright": LeftToRight,
+		"righttoleft": RightToLeft,
+		"toptobottom": TopToBottom,
+		"bottomtotop": BottomToTop,
+	})
+	if err != nil {
+		return nil, err
+	}
+	mode, err := choice(p, "mode", SlidePush, map[string]SlideMode{
+		"push":   SlidePush,
+		"cover":  SlideCover,
+		"reveal": SlideReveal,
+	})
+	if err != nil {
+		return nil, err
+	}
+	t := NewTimelineSlideTransition[T](direction, timeline, opts...).SetMode(mode)
+	if p.Has("angle") {
+		angle, err := p.Float("angle", 0)
+		if err != nil {
+			return nil, err
+		}
+		t.SetAngle(angle * math.Pi / 180)
+	}
+	if p.Has("parallax") {
+		speed, err := p.Float("parallax", 0)
+		if err != nil {
+			return nil, err
+		}
+		t.SetParallax(speed)
+	}
+	return t, nil
+}
+
+// shaderFactory accepts "uniforms", a map of numbers or lists of numbers
+func shaderFactory[T any](constructor func(*Timeline, ...TransitionOption) *ShaderTransition[T]) TransitionFactory[T] {
+	return func(p TransitionParams) (SceneTransition[T], error) {
+		timeline, opts, err := timelineAndOptions(p)
+		if err != nil {
+			return nil, err
+		}
+		uniforms, _ := p["uniforms"].(map[string]any)
+		if p.Has("uniforms") && uniforms == nil {
+			return nil, p.invalid("uniforms")
+		}
+		t := constructor(timeline, opts...)
+		for name, value := range uniforms {
+			switch v := value.(type) {
+			case []any:
+				values := make([]float32, len(v))
+				for i, e := range v {
+					f, ok := toFloat(e)
+					if !ok {
+						return nil, p.invalid("uniforms")
+					}
+					values[i] = float32(f)
+				}
+				t.SetUniform(name, values)
+			default:
+				f, ok := toFloat(v)
+				if !ok {
+					return nil, p.invalid("uniforms")
+				}
+				t.SetUniform(name, float32(f))
+			}
+		}
+		return t, nil
+	}
+}
+
+// maskFactory accepts a "softness" and "inverted", linear wipes also accept an "angle" in degrees
+func maskFactory[T any](radial bool) TransitionFactory[T] {
+	return func(p TransitionParams) (SceneTransition[T], error) {
+		timeline, opts, err := timelineAndOptions(p)
+		if err != nil {
+			return nil, err
+		}
+		generator := RadialGradientMask()
+		if !radial {
+			angle, err := p.Float("angle", 0)
+			if err != nil {
+				return nil, err
+			}
+			generator = LinearGradientMask(angle * math.Pi / 180)
+		}
+		softness, err := p.Float("softness", .1)
+		if err != nil {
+			return nil, err
+		}
+		inverted, err := p.Bool("inverted", false)
+		if err != nil {
+			return nil, err
+		}
+		return NewGeneratedMaskTransition[T](generator, timeline, opts...).SetSoftness(softness).SetInverted(inverted), nil
+	}
+}
+
+// irisFactory accepts a "shape", a "mode" and the "points" of a star
+func irisFactory[T any](p TransitionParams) (SceneTransition[T], error) {
+	timeline, opts, err := timelineAndOptions(p)
+	if err != nil {
+		return nil, err
+	}
+	// The star is built from its points below, so the other shapes don't depend on them
+	shape, err := choice(p, "shape", Shape(CircleShape), map[string]Shape{
+		"circle":  CircleShape,
+		"diamond": DiamondShape,
+		"star":    nil,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if shape == nil {
+		points, err := p.Float("points", 5)
+		if err != nil {
+			return nil, err
+		}
+		if points < 3 {
+			return nil, p.invalid("points")
+		}
+		shape = StarShape(int(points), .5)
+	}
+	mode, err := choice(p, "mode", IrisOpen, map[string]IrisMode{
+		"open":  IrisOpen,
+		"close": IrisClose,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return NewIrisTransition[T](shape, mode, timeline, opts...), nil
+}
+
+func timelineAndOptions(p TransitionParams) (*Timeline, []TransitionOption, error) {
+	timeline, err := p.Timeline()
+	if err != nil {
+		return nil, nil, err
+	}
+	opts, err := p.Options()
+	return timeline, opts, err
+}
@@ -0,0 +1,14 @@
+package stagehand
+
+import "github.com/joelschutz/stagehand/ruleset"
+
+var ErrNoPath = ruleset.ErrNoPath
+
+// A PathStep is a directive fired on the way between two scenes, scenes are labeled as in the diagrams
+type PathStep = ruleset.PathStep
+
+// ShortestPath returns the shortest sequence of directives between the scenes with the labels, their
+// IDs for the scenes built by the registry
+func (d *SceneDirector[T]) ShortestPath(from, to string) ([]PathStep, error) {
+	return d.graph().ShortestPath(from, to)
+}
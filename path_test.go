@@ -0,0 +1,39 @@
+package stagehand
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSceneDirector_ShortestPath(t *testing.T) {
+	director := newExportDirector(t)
+
+	path, err := director.ShortestPath("level", "level")
+	assert.NoError(t, err)
+	assert.Empty(t, path)
+
+	path, err = director.ShortestPath("level", "menu")
+	assert.NoError(t, err)
+	assert.Equal(t, []PathStep{{From: "level", To: "menu", Trigger: 7, Guarded: true}}, path)
+
+	play, _ := director.TriggerRegistry().Lookup("play")
+	path, err = director.ShortestPath("menu", "level")
+	assert.NoError(t, err)
+	assert.Equal(t, []PathStep{{From: "menu", To: "level", Trigger: play, Transition: "FadeTransition"}}, path)
+
+	_, err = director.ShortestPath("menu", "boss")
+	assert.ErrorIs(t, err, ErrUnknownScene)
+
+	director.IDRuleSet["level"] = nil
+	_, err = director.ShortestPath("level", "menu")
+	assert.ErrorIs(t, err, ErrNoPath)
+}
+
+func TestSceneDirector_WriteText(t *testing.T) {
+	director := newExportDirector(t)
+	var b strings.Builder
+	assert.NoError(t, director.WriteText(&b))
+	assert.Equal(t, "level\n  7 [guarded] -> menu\nmenu (start, current)\n  play / FadeTransition -> level\n", b.String())
+}
@@ -0,0 +1,158 @@
+package stagehand
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/joelschutz/stagehand/ruleset"
+)
+
+var (
+	ErrUnknownScene   = ruleset.ErrUnknownScene
+	ErrDuplicateScene = errors.New("stagehand: scene already registered")
+	ErrNoRegistry     = errors.New("stagehand: no registry set")
+)
+
+// A SceneID is the stable name of a scene in a Registry
+type SceneID string
+
+// A SceneFactory builds a scene of a Registry
+type SceneFactory[T any] func() Scene[T]
+
+type registration[T any] struct {
+	factory   SceneFactory[T]
+	singleton bool
+}
+
+// A Registry maps scene IDs to the factories that build them, so scenes can be referenced, and
+// serialized, by ID and built when they are visited
+type Registry[T any] struct {
+	registrations map[SceneID]registration[T]
+	instances     map[SceneID]Scene[T] // last scene built for each ID
+}
+
+func NewRegistry[T any]() *Registry[T] {
+	return &Registry[T]{
+		registrations: make(map[SceneID]registration[T]),
+		instances:     make(map[SceneID]Scene[T]),
+	}
+}
+
+// Register adds a factory that builds a fresh scene on every visit
+func (r *Registry[T]) Register(id SceneID, factory SceneFactory[T]) error {
+	return r.register(id, registration[T]{factory: factory})
+}
+
+// RegisterSingleton adds a factory that builds the scene on the first visit and reuses it afterwards
+func (r *Registry[T]) RegisterSingleton(id SceneID, factory SceneFactory[T]) error {
+	return r.register(id, registration[T]{factory: factory, singleton: true})
+}
+
+// RegisterScene adds a scene that is reused on every visit
+func (r *Registry[T]) RegisterScene(id SceneID, scene Scene[T]) error {
+	if err := r.RegisterSingleton(id, func() Scene[T] { return scene }); err != nil {
+		return err
+	}
+	r.instances[id] = scene
+	return nil
+}
+
+func (r *Registry[T]) register(id SceneID, reg registration[T]) error {
+	if _, ok := r.registrations[id]; ok {
+		return fmt.Errorf("%w: %q", ErrDuplicateScene, id)
+	}
+	r.registrations[id] = reg
+	return nil
+}
+
+// Resolve returns the scene to visit for the ID, singletons are built only once
+func (r *Registry[T]) Resolve(id SceneID) (Scene[T], error) {
+	reg, ok := r.registrations[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownScene, id)
+	}
+	if scene, ok := r.instances[id]; ok && reg.singleton {
+		return scene, nil
+	}
+	scene := reg.factory()
+	r.instances[id] = scene
+	return scene, nil
+}
+
+// IDOf returns the ID of a scene built by the registry, only the last scene built for each ID is known
+func (r *Registry[T]) IDOf(scene Scene[T]) (SceneID, bool) {
+	scene = unwrapScene(scene)
+	for id, instance := range r.instances {
+		if instance == scene {
+			return id, true
+		}
+	}
+	return "", false
+}
+
+// Has reports whether the ID is registered
+func (r *Registry[T]) Has(id SceneID) bool {
+	_, ok := r.registrations[id]
+	return ok
+}
+
+// IDs returns the registered IDs in no particular order
+func (r *Registry[T]) IDs() []SceneID {
+	ids := make([]SceneID, 0, len(r.registrations))
+	for id := range r.registrations {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// SetRegistry sets the registry used to switch to scenes by ID
+func (s *SceneManager[T]) SetRegistry(registry *Registry[T]) {
+	s.registry = registry
+}
+
+func (s *SceneManager[T]) Registry() *Registry[T] {
+	return s.registry
+}
+
+// SwitchToID switches to the scene registered with the ID
+func (s *SceneManager[T]) SwitchToID(id SceneID) error {
+	return s.SwitchToIDWithTransition(id, nil)
+}
+
+// SwitchToIDWithTransition switches to the scene registered with the ID with an optional transition.
+// The scene is only built once the interrupt policy accepts the switch
+func (s *SceneManager[T]) SwitchToIDWithTransition(id SceneID, transition SceneTransition[T]) error {
+	if s.registry == nil {
+		return ErrNoRegistry
+	}
+	if !s.registry.Has(id) {
+		return fmt.Errorf("%w: %q", ErrUnknownScene, id)
+	}
+	origin, ok := s.interrupt(s.policy, func() { s.SwitchToIDWithTransition(id, transition) })
+	if !ok {
+		return nil
+	}
+	scene, err := s.resolve(id)
+	if err != nil {
+		return err
+	}
+	s.switchFrom(s.controller(), origin, scene, transition)
+	return nil
+}
+
+// CurrentID returns the ID of the current scene, it fails while a transition is running or if the
+// scene was not built by the registry
+func (s *SceneManager[T]) CurrentID() (SceneID, bool) {
+	scene, ok := s.current.(Scene[T])
+	if !ok || s.registry == nil {
+		return "", false
+	}
+	return s.registry.IDOf(scene)
+}
+
+func (s *SceneManager[T]) resolve(id SceneID) (Scene[T], error) {
+	if s.registry == nil {
+		return nil, ErrNoRegistry
+	}
+	return s.registry.Resolve(id)
+}
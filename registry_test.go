@@ -0,0 +1,172 @@
+package stagehand
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry_Resolve(t *testing.T) {
+	r := NewRegistry[int]()
+	assert.NoError(t, r.Register("level", func() Scene[int] { return &MockScene{} }))
+	assert.NoError(t, r.RegisterSingleton("menu", func() Scene[int] { return &MockScene{} }))
+	assert.True(t, r.Has("level"))
+	assert.ElementsMatch(t, []SceneID{"level", "menu"}, r.IDs())
+
+	// Fresh scenes are built on every visit
+	levelA, err := r.Resolve("level")
+	assert.NoError(t, err)
+	levelB, _ := r.Resolve("level")
+	assert.NotSame(t, levelA, levelB)
+
+	// Singletons are built once
+	menuA, err := r.Resolve("menu")
+	assert.NoError(t, err)
+	menuB, _ := r.Resolve("menu")
+	assert.Same(t, menuA, menuB)
+
+	id, ok := r.IDOf(levelB)
+	assert.True(t, ok)
+	assert.Equal(t, SceneID("level"), id)
+	_, ok = r.IDOf(levelA)
+	assert.False(t, ok)
+}
+
+func TestRegistry_Errors(t *testing.T) {
+	r := NewRegistry[int]()
+	scene := &MockScene{}
+	assert.NoError(t, r.RegisterScene("menu", scene))
+	assert.ErrorIs(t, r.Register("menu", func() Scene[int] { return &MockScene{} }), ErrDuplicateScene)
+
+	_, err := r.Resolve("missing")
+	assert.ErrorIs(t, err, ErrUnknownScene)
+
+	id, ok := r.IDOf(scene)
+	assert.True(t, ok)
+	assert.Equal(t, SceneID("menu"), id)
+}
+
+func TestSceneManager_SwitchToID(t *testing.T) {
+	sm := NewSceneManager[int](&MockScene{}, 0)
+	assert.ErrorIs(t, sm.SwitchToID("menu"), ErrNoRegistry)
+
+	r := NewRegistry[int]()
+	scene := &MockScene{}
+	r.RegisterScene("menu", scene)
+	sm.SetRegistry(r)
+	assert.Equal(t, r, sm.Registry())
+
+	assert.NoError(t, sm.SwitchToID("menu"))
+	assert.Equal(t, scene, sm.current)
+	id, ok := sm.CurrentID()
+	assert.True(t, ok)
+	assert.Equal(t, SceneID("menu"), id)
+
+	assert.ErrorIs(t, sm.SwitchToID("missing"), ErrUnknownScene)
+	assert.Equal(t, scene, sm.current)
+
+	trans := &baseTransitionImplementation{}
+	r.Register("level", func() Scene[int] { return &MockScene{} })
+	assert.NoError(t, sm.SwitchToIDWithTransition("level", trans))
+	assert.Equal(t, trans, sm.current)
+	_, ok = sm.CurrentID()
+	assert.False(t, ok)
+}
+
+func TestSceneManager_SwitchToIDIgnored(t *testing.T) {
+	r := NewRegistry[int]()
+	built := 0
+	r.Register("level", func() Scene[int] { built++; return &MockScene{} })
+	menu := &MockScene{}
+	r.RegisterScene("menu", menu)
+	sm := NewSceneManager[int](menu, 0)
+	sm.SetRegistry(r)
+	sm.SetInterruptPolicy(InterruptIgnore)
+
+	trans := &baseTransitionImplementation{}
+	assert.NoError(t, sm.SwitchToIDWithTransition("level", trans))
+	assert.Equal(t, 1, built)
+	level := r.instances["level"]
+
+	// The dropped switch doesn't build a scene
+	assert.NoError(t, sm.SwitchToID("level"))
+	assert.Equal(t, 1, built)
+	assert.Same(t, level, r.instances["level"])
+	assert.ErrorIs(t, sm.SwitchToID("missing"), ErrUnknownScene)
+
+	trans.End()
+	id, _ := sm.CurrentID()
+	assert.Equal(t, SceneID("level"), id)
+}
+
+func TestSceneDirector_IDRuleSet(t *testing.T) {
+	r := NewRegistry[int]()
+	r.RegisterSingleton("menu", func() Scene[int] { return &MockScene{} })
+	r.Register("level", func() Scene[int] { return &MockScene{} })
+
+	director, err := NewSceneDirectorWithRegistry[int](r, "menu", 0, map[SceneID][]Directive[int]{
+		"menu":  {{DestID: "level", Trigger: 1}},
+		"level": {{DestID: "menu", Trigger: 2}, {DestID: "missing", Trigger: 3}},
+	})
+	assert.NoError(t, err)
+	menu, _ := r.Resolve("menu")
+	assert.Same(t, menu, director.current)
+
+	director.ProcessTrigger(1)
+	first := director.current
+	id, _ := director.CurrentID()
+	assert.Equal(t, SceneID("level"), id)
+
+	// Unknown IDs are reported
+	_, err = director.ProcessTrigger(3)
+	assert.ErrorIs(t, err, ErrUnknownScene)
+	assert.Same(t, first, director.current)
+
+	director.ProcessTrigger(2)
+	assert.Same(t, menu, director.current)
+
+	// Each visit gets a fresh scene
+	director.ProcessTrigger(1)
+	assert.NotSame(t, first, director.current)
+
+	_, err = NewSceneDirectorWithRegistry[int](r, "missing", 0, nil)
+	assert.ErrorIs(t, err, ErrUnknownScene)
+}
+
+func TestSceneDirector_SwitchToID(t *testing.T) {
+	r := NewRegistry[int]()
+	r.RegisterSingleton("menu", func() Scene[int] { return &MockScene{} })
+	r.RegisterSingleton("level", func() Scene[int] { return &MockScene{} })
+	director, err := NewSceneDirectorWithRegistry[int](r, "menu", 0, map[SceneID][]Directive[int]{
+		"level": {{DestID: "menu", Trigger: 1}},
+	})
+	assert.NoError(t, err)
+
+	// Scenes restored by ID get the director, so they can process triggers
+	assert.NoError(t, director.SwitchToID("level"))
+	level, _ := r.Resolve("level")
+	assert.Same(t, director, level.(*MockScene).sm)
+	director.ProcessTrigger(1)
+	id, _ := director.CurrentID()
+	assert.Equal(t, SceneID("menu"), id)
+}
+
+func TestSceneDirector_RuleSetBeforeIDRuleSet(t *testing.T) {
+	r := NewRegistry[int]()
+	menu, dest := &MockScene{}, &MockScene{}
+	r.RegisterScene("menu", menu)
+	r.RegisterScene("dest", dest)
+
+	director := NewSceneDirector[int](menu, 0, map[Scene[int]][]Directive[int]{
+		menu: {{Dest: dest, Trigger: 1}},
+	})
+	director.SetRegistry(r)
+	director.IDRuleSet = map[SceneID][]Directive[int]{
+		"menu": {{DestID: "menu", Trigger: 1}},
+	}
+	directives := director.directives(menu)
+	assert.Len(t, directives, 2)
+	assert.Same(t, dest, directives[0].Dest)
+	assert.Equal(t, SceneID("menu"), directives[1].DestID)
+	assert.Len(t, director.RuleSet[menu], 1)
+}
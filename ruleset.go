@@ -0,0 +1,208 @@
+package stagehand
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/joelschutz/stagehand/ruleset"
+)
+
+var ErrUnknownGuard = errors.New("stagehand: unknown guard")
+
+// A RuleSetConfig is the data form of the rules of a SceneDirector, scenes and destinations are
+// referenced by ID and triggers by name
+type RuleSetConfig = ruleset.Config
+
+// A DirectiveConfig is the data form of a Directive, the transition and the guard are optional. A
+// guard name prefixed with "!" negates the guard
+type DirectiveConfig = ruleset.DirectiveConfig
+
+// ParseRuleSetJSON decodes a rule set from JSON, unknown fields are rejected
+func ParseRuleSetJSON(data []byte) (RuleSetConfig, error) {
+	return ruleset.ParseJSON(data)
+}
+
+// ParseRuleSetYAML decodes a rule set from YAML, unknown fields are rejected
+func ParseRuleSetYAML(data []byte) (RuleSetConfig, error) {
+	return ruleset.ParseYAML(data)
+}
+
+// A RuleSetLoader builds directors from rule sets, resolving scenes with a registry, trigger names
+// with a trigger registry and transitions with the registered factories
+type RuleSetLoader[T any] struct {
+	registry    *Registry[T]
+	triggers    *TriggerRegistry
+	transitions map[string]TransitionFactory[T]
+	guards      map[string]func(state T) bool
+}
+
+// NewRuleSetLoader returns a loader with the built-in transitions registered, resolving trigger names
+// with Triggers
+func NewRuleSetLoader[T any](registry *Registry[T]) *RuleSetLoader[T] {
+	return &RuleSetLoader[T]{
+		registry:    registry,
+		triggers:    Triggers,
+		transitions: builtinTransitions[T](),
+		guards:      make(map[string]func(state T) bool),
+	}
+}
+
+// SetTriggerRegistry sets the registry trigger names are resolved with, the directors built by the
+// loader name their triggers with it too
+func (l *RuleSetLoader[T]) SetTriggerRegistry(triggers *TriggerRegistry) {
+	l.triggers = triggers
+}
+
+func (l *RuleSetLoader[T]) TriggerRegistry() *TriggerRegistry {
+	return l.triggers
+}
+
+// RegisterTrigger names a trigger in the trigger registry of the loader, unnamed triggers can be
+// referenced by their number too
+func (l *RuleSetLoader[T]) RegisterTrigger(name string, trigger SceneTransitionTrigger) error {
+	return l.triggers.Register(name, trigger)
+}
+
+// RegisterTransition adds a transition type, it replaces the built-in type with the same name. Names
+// are case insensitive
+func (l *RuleSetLoader[T]) RegisterTransition(name string, factory TransitionFactory[T]) {
+	l.transitions[ruleset.NormalizeName(name)] = factory
+}
+
+// RegisterGuard names a guard for the directives of the rule sets
+func (l *RuleSetLoader[T]) RegisterGuard(name string, guard func(state T) bool) {
+	l.guards[name] = guard
+}
+
+// Transition builds a new transition from its parameters
+func (l *RuleSetLoader[T]) Transition(params TransitionParams) (SceneTransition[T], error) {
+	factory, ok := l.transitions[ruleset.NormalizeName(params.Type())]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownTransition, params.Type())
+	}
+	return factory(params)
+}
+
+// Trigger resolves the name of a trigger
+func (l *RuleSetLoader[T]) Trigger(name string) (SceneTransitionTrigger, error) {
+	return l.triggers.Resolve(name)
+}
+
+// Build returns the directives of the rule set keyed by scene ID. Every directive gets its own
+// transition, and every scene and destination must be registered
+func (l *RuleSetLoader[T]) Build(config RuleSetConfig) (map[SceneID][]Directive[T], error) {
+	rules := make(map[SceneID][]Directive[T], len(config.Scenes))
+	for id, configs := range config.Scenes {
+		if !l.registry.Has(SceneID(id)) {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownScene, id)
+		}
+		directives, err := l.directives(fmt.Sprintf("scene %q", id), configs)
+		if err != nil {
+			return nil, err
+		}
+		rules[SceneID(id)] = directives
+	}
+	return rules, nil
+}
+
+// directives builds the directives of the scope named in the errors
+func (l *RuleSetLoader[T]) directives(scope string, configs []DirectiveConfig) ([]Directive[T], error) {
+	directives := make([]Directive[T], len(configs))
+	for i, c := range configs {
+		directive, err := l.directive(c)
+		if err != nil {
+			return nil, fmt.Errorf("stagehand: %s directive %d: %w", scope, i, err)
+		}
+		directives[i] = directive
+	}
+	return directives, nil
+}
+
+func (l *RuleSetLoader[T]) directive(c DirectiveConfig) (Directive[T], error) {
+	directive := Directive[T]{DestID: SceneID(c.Dest), Priority: c.Priority, Except: c.Except}
+	if !l.registry.Has(directive.DestID) {
+		return directive, fmt.Errorf("%w: %q", ErrUnknownScene, c.Dest)
+	}
+	trigger, err := l.Trigger(c.Trigger)
+	if err != nil {
+		return directive, err
+	}
+	directive.Trigger = trigger
+	if c.Guard != "" {
+		if directive.Guard, err = l.guard(c.Guard); err != nil {
+			return directive, err
+		}
+	}
+	if c.Transition != nil {
+		if directive.Transition, err = l.Transition(TransitionParams(c.Transition)); err != nil {
+			return directive, err
+		}
+	}
+	return directive, nil
+}
+
+func (l *RuleSetLoader[T]) guard(name string) (func(state T) bool, error) {
+	name, negated := strings.CutPrefix(name, "!")
+	guard, ok := l.guards[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownGuard, name)
+	}
+	if negated {
+		return func(state T) bool { return !guard(state) }, nil
+	}
+	return guard, nil
+}
+
+// NewDirector returns a director that starts at the start scene of the rule set and follows its rules
+func (l *RuleSetLoader[T]) NewDirector(config RuleSetConfig, state T) (*SceneDirector[T], error) {
+	rules, err := l.Build(config)
+	if err != nil {
+		return nil, err
+	}
+	groupRules := make(map[string][]Directive[T], len(config.Groups))
+	for group, configs := range config.Groups {
+		if groupRules[group], err = l.directives(fmt.Sprintf("group %q", group), configs); err != nil {
+			return nil, err
+		}
+	}
+	globalRules, err := l.directives("global", config.Global)
+	if err != nil {
+		return nil, err
+	}
+	for id := range config.Tags {
+		if !l.registry.Has(SceneID(id)) {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownScene, id)
+		}
+	}
+
+	director, err := NewSceneDirectorWithRegistry[T](l.registry, SceneID(config.Start), state, rules)
+	if err != nil {
+		return nil, err
+	}
+	director.SetTriggerRegistry(l.triggers)
+	director.GroupRuleSet = groupRules
+	director.GlobalRuleSet = globalRules
+	for id, groups := range config.Tags {
+		director.TagID(SceneID(id), groups...)
+	}
+	return director, nil
+}
+
+// LoadJSON returns a director following the rule set decoded from JSON
+func (l *RuleSetLoader[T]) LoadJSON(data []byte, state T) (*SceneDirector[T], error) {
+	config, err := ParseRuleSetJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	return l.NewDirector(config, state)
+}
+
+// LoadYAML returns a director following the rule set decoded from YAML
+func (l *RuleSetLoader[T]) LoadYAML(data []byte, state T) (*SceneDirector[T], error) {
+	config, err := ParseRuleSetYAML(data)
+	if err != nil {
+		return nil, err
+	}
+	return l.NewDirector(config, state)
+}
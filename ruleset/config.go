@@ -0,0 +1,98 @@
+// Package ruleset describes the rule sets of a SceneDirector as plain data, and analyzes and exports
+// the flow between their scenes. It doesn't depend on Ebitengine, so tools can use it without a display
+package ruleset
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var ErrUnknownTransition = errors.New("stagehand: unknown transition type")
+
+// TransitionTypes are the names of the built-in transitions, normalized with NormalizeName
+var TransitionTypes = []string{
+	"crossfade", "dissolve", "fade", "iris", "linearmask", "pixelsort", "radialmask", "radialwipe", "ripple", "slide",
+}
+
+// A Config is the data form of the rules of a SceneDirector, scenes and destinations are referenced by
+// ID and triggers by name
+type Config struct {
+	Start  string                       `json:"start" yaml:"start"`
+	Scenes map[string][]DirectiveConfig `json:"scenes" yaml:"scenes"`
+	Groups map[string][]DirectiveConfig `json:"groups,omitempty" yaml:"groups,omitempty"`
+	Global []DirectiveConfig            `json:"global,omitempty" yaml:"global,omitempty"`
+	Tags   map[string][]string          `json:"tags,omitempty" yaml:"tags,omitempty"` // Groups of each scene
+}
+
+// A DirectiveConfig is the data form of a Directive, the transition and the guard are optional. A
+// guard name prefixed with "!" negates the guard
+type DirectiveConfig struct {
+	Trigger    string         `json:"trigger" yaml:"trigger"`
+	Dest       string         `json:"dest" yaml:"dest"`
+	Transition map[string]any `json:"transition,omitempty" yaml:"transition,omitempty"` // The "type" key names its constructor
+	Guard      string         `json:"guard,omitempty" yaml:"guard,omitempty"`
+	Priority   int            `json:"priority,omitempty" yaml:"priority,omitempty"`
+	Except     []string       `json:"except,omitempty" yaml:"except,omitempty"`
+}
+
+// ParseJSON decodes a rule set from JSON, unknown fields are rejected
+func ParseJSON(data []byte) (Config, error) {
+	var config Config
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&config); err != nil {
+		return config, fmt.Errorf("stagehand: decoding rule set: %w", err)
+	}
+	return config, nil
+}
+
+// ParseYAML decodes a rule set from YAML, unknown fields are rejected
+func ParseYAML(data []byte) (Config, error) {
+	var config Config
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&config); err != nil {
+		return config, fmt.Errorf("stagehand: decoding rule set: %w", err)
+	}
+	return config, nil
+}
+
+// Directives returns every directive of the rule set: the global ones, then the ones of the scenes
+// and the groups sorted by name
+func (c Config) Directives() []*DirectiveConfig {
+	var directives []*DirectiveConfig
+	add := func(configs []DirectiveConfig) {
+		for i := range configs {
+			directives = append(directives, &configs[i])
+		}
+	}
+	add(c.Global)
+	for _, id := range sortedKeys(c.Scenes) {
+		add(c.Scenes[id])
+	}
+	for _, group := range sortedKeys(c.Groups) {
+		add(c.Groups[group])
+	}
+	return directives
+}
+
+// NormalizeName makes names case insensitive and ignores separators, so "leftToRight" and
+// "left-to-right" match
+func NormalizeName(name string) string {
+	return strings.NewReplacer("-", "", "_", "", " ", "").Replace(strings.ToLower(name))
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,139 @@
+package ruleset
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// A flowchart is the graph prepared for the exporters, scenes are indexed by node
+type flowchart struct {
+	labels  []string
+	start   int
+	current int // -1 if no scene is running
+	edges   []flowEdge
+}
+
+type flowEdge struct {
+	from, to int
+	trigger  string
+	rule     Rule
+}
+
+func (e flowEdge) label() string {
+	label := e.trigger
+	if e.rule.Guarded {
+		label += " [guarded]"
+	}
+	if e.rule.Transition != "" {
+		label += " / " + e.rule.Transition
+	}
+	return label
+}
+
+// flowchart builds the flowchart of the graph, the directives that never fire are left out
+func (g *Graph) flowchart() *flowchart {
+	f := &flowchart{labels: g.Scenes, start: -1, current: -1}
+	for i, label := range f.labels {
+		if label == g.Start {
+			f.start = i
+		}
+		if label == g.Current {
+			f.current = i
+		}
+	}
+	for from, label := range f.labels {
+		for _, rule := range g.edges(label) {
+			if to := f.node(rule.Dest); to >= 0 {
+				f.edges = append(f.edges, flowEdge{from: from, to: to, trigger: g.name(rule.Trigger), rule: rule})
+			}
+		}
+	}
+	return f
+}
+
+// node returns the index of the scene with the label, or -1
+func (f *flowchart) node(label string) int {
+	for i, l := range f.labels {
+		if l == label {
+			return i
+		}
+	}
+	return -1
+}
+
+// WriteDOT writes the flow between the scenes as a Graphviz DOT digraph. Edges are labeled with the
+// trigger and the transition of the directives, and the current scene is highlighted
+func (g *Graph) WriteDOT(w io.Writer) error {
+	f := g.flowchart()
+	b := bufio.NewWriter(w)
+	fmt.Fprintln(b, "digraph stagehand {")
+	fmt.Fprintln(b, "\trankdir=LR;")
+	fmt.Fprintln(b, "\tnode [shape=box, style=rounded];")
+	fmt.Fprintln(b, "\tstart [shape=point];")
+	for i, label := range f.labels {
+		attrs := "label=" + strconv.Quote(label)
+		if i == f.current {
+			attrs += `, style="rounded,filled", fillcolor=lightblue`
+		}
+		fmt.Fprintf(b, "\tn%d [%s];\n", i, attrs)
+	}
+	if f.start >= 0 {
+		fmt.Fprintf(b, "\tstart -> n%d;\n", f.start)
+	}
+	for _, e := range f.edges {
+		fmt.Fprintf(b, "\tn%d -> n%d [label=%s];\n", e.from, e.to, strconv.Quote(e.label()))
+	}
+	fmt.Fprintln(b, "}")
+	return b.Flush()
+}
+
+// WriteMermaid writes the flow between the scenes as a Mermaid state diagram. Edges are labeled with
+// the trigger and the transition of the directives, and the current scene is highlighted
+func (g *Graph) WriteMermaid(w io.Writer) error {
+	f := g.flowchart()
+	b := bufio.NewWriter(w)
+	fmt.Fprintln(b, "stateDiagram-v2")
+	for i, label := range f.labels {
+		fmt.Fprintf(b, "    state %s as s%d\n", strconv.Quote(label), i)
+	}
+	if f.start >= 0 {
+		fmt.Fprintf(b, "    [*] --> s%d\n", f.start)
+	}
+	for _, e := range f.edges {
+		// Colons end the label of a transition in Mermaid
+		fmt.Fprintf(b, "    s%d --> s%d : %s\n", e.from, e.to, strings.ReplaceAll(e.label(), ":", "#58;"))
+	}
+	if f.current >= 0 {
+		fmt.Fprintln(b, "    classDef current fill:#add8e6")
+		fmt.Fprintf(b, "    class s%d current\n", f.current)
+	}
+	return b.Flush()
+}
+
+// WriteText writes the flow between the scenes as plain text, every scene followed by its directives
+func (g *Graph) WriteText(w io.Writer) error {
+	f := g.flowchart()
+	b := bufio.NewWriter(w)
+	for i, label := range f.labels {
+		var marks []string
+		if i == f.start {
+			marks = append(marks, "start")
+		}
+		if i == f.current {
+			marks = append(marks, "current")
+		}
+		if len(marks) > 0 {
+			label += " (" + strings.Join(marks, ", ") + ")"
+		}
+		fmt.Fprintln(b, label)
+		for _, e := range f.edges {
+			if e.from == i {
+				fmt.Fprintf(b, "  %s -> %s\n", e.label(), f.labels[e.to])
+			}
+		}
+	}
+	return b.Flush()
+}
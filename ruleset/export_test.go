@@ -0,0 +1,46 @@
+package ruleset
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraph_WriteText(t *testing.T) {
+	g := newGraph(t)
+	g.Current = "level"
+	var b strings.Builder
+	assert.NoError(t, g.WriteText(&b))
+	assert.Equal(t, `credits
+level (current)
+  pause -> menu
+  quit -> credits
+menu (start)
+  play / fade -> level
+  options [guarded] -> settings
+  quit -> credits
+settings
+  back -> level
+  quit -> credits
+`, b.String())
+}
+
+func TestGraph_WriteDOT(t *testing.T) {
+	g := newGraph(t)
+	var b strings.Builder
+	assert.NoError(t, g.WriteDOT(&b))
+	assert.Contains(t, b.String(), "\tstart -> n2;\n")
+	assert.Contains(t, b.String(), "\tn2 -> n1 [label=\"play / fade\"];\n")
+	assert.NotContains(t, b.String(), "fillcolor")
+}
+
+func TestGraph_WriteMermaid(t *testing.T) {
+	g := newGraph(t)
+	g.Current = "menu"
+	var b strings.Builder
+	assert.NoError(t, g.WriteMermaid(&b))
+	assert.Contains(t, b.String(), "    [*] --> s2\n")
+	assert.Contains(t, b.String(), "    s2 --> s3 : options [guarded]\n")
+	assert.Contains(t, b.String(), "    class s2 current\n")
+}
@@ -0,0 +1,179 @@
+package ruleset
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+var ErrUnknownScene = errors.New("stagehand: unknown scene")
+
+// A Graph is the flow between the scenes of a director, scenes are named by their labels: their IDs,
+// or their types if they have none
+type Graph struct {
+	Start    string
+	Current  string            // empty if no scene is running, e.g. during a transition
+	Scenes   []string          // every known scene, in the order they are listed
+	Rules    map[string][]Rule // directives of each scene, in the order they are checked
+	Triggers *TriggerRegistry  // names the triggers, Triggers if nil
+}
+
+// A Rule is a directive of a scene in a Graph
+type Rule struct {
+	Trigger    Trigger
+	Dest       string // label of the destination, empty if it's missing
+	DestID     string // ID of the destination if it's not a known scene
+	Guarded    bool
+	Transition string // type of the transition, empty if the directive has none
+	Directive  int    // identifies the directive, group and global directives are listed in many scenes
+	Instance   int    // identifies the transition, 0 if the directive has none
+}
+
+// NewGraph returns the flow of the rule set, resolving the trigger names with the registry, or
+// Triggers if it's nil. Every ID in the rule set is a known scene, and every directive gets its own
+// transition, labeled by its type
+func NewGraph(config Config, triggers *TriggerRegistry) (*Graph, error) {
+	if triggers == nil {
+		triggers = Triggers
+	}
+	if config.Start == "" {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownScene, config.Start)
+	}
+
+	// Triggers are resolved by scope first, so errors point to the directive in the file
+	resolved := make(map[*DirectiveConfig]Trigger)
+	resolve := func(scope string, configs []DirectiveConfig) error {
+		for i := range configs {
+			trigger, err := triggers.Resolve(configs[i].Trigger)
+			if err != nil {
+				return fmt.Errorf("stagehand: %s directive %d: %w", scope, i, err)
+			}
+			resolved[&configs[i]] = trigger
+		}
+		return nil
+	}
+	for _, id := range sortedKeys(config.Scenes) {
+		if err := resolve(fmt.Sprintf("scene %q", id), config.Scenes[id]); err != nil {
+			return nil, err
+		}
+	}
+	for _, group := range sortedKeys(config.Groups) {
+		if err := resolve(fmt.Sprintf("group %q", group), config.Groups[group]); err != nil {
+			return nil, err
+		}
+	}
+	if err := resolve("global", config.Global); err != nil {
+		return nil, err
+	}
+
+	g := &Graph{Start: config.Start, Rules: make(map[string][]Rule), Triggers: triggers}
+	seen := make(map[string]bool)
+	add := func(id string) {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			g.Scenes = append(g.Scenes, id)
+		}
+	}
+	add(config.Start)
+	for id := range config.Scenes {
+		add(id)
+	}
+	for id := range config.Tags {
+		add(id)
+	}
+	ids := make(map[*DirectiveConfig]int)
+	for i, c := range config.Directives() {
+		ids[c] = i + 1
+		add(c.Dest)
+	}
+	sort.Strings(g.Scenes)
+
+	for _, id := range g.Scenes {
+		for _, c := range config.directives(id) {
+			rule := Rule{Trigger: resolved[c], Dest: c.Dest, Guarded: c.Guard != "", Directive: ids[c]}
+			if c.Transition != nil {
+				rule.Transition, _ = c.Transition["type"].(string)
+				rule.Instance = rule.Directive
+			}
+			g.Rules[id] = append(g.Rules[id], rule)
+		}
+	}
+	return g, nil
+}
+
+// directives returns the directives of the scene sorted by priority, as a director checks them
+func (c Config) directives(id string) []*DirectiveConfig {
+	groups := append(c.Tags[id][:len(c.Tags[id]):len(c.Tags[id])], id)
+	var directives []*DirectiveConfig
+	add := func(configs []DirectiveConfig) {
+		for i := range configs {
+			if !excluded(configs[i].Except, groups) {
+				directives = append(directives, &configs[i])
+			}
+		}
+	}
+
+	add(c.Scenes[id])
+	seen := make(map[string]bool, len(groups))
+	for _, group := range groups {
+		if !seen[group] {
+			seen[group] = true
+			add(c.Groups[group])
+		}
+	}
+	add(c.Global)
+
+	sort.SliceStable(directives, func(i, j int) bool {
+		return directives[i].Priority > directives[j].Priority
+	})
+	return directives
+}
+
+func excluded(except, groups []string) bool {
+	for _, e := range except {
+		for _, group := range groups {
+			if e == group {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// shadowed returns which rules of the scene never fire, a rule without a guard always matches its
+// trigger
+func (g *Graph) shadowed(scene string) []bool {
+	rules := g.Rules[scene]
+	shadowed := make([]bool, len(rules))
+	unguarded := make(map[Trigger]bool)
+	for i, rule := range rules {
+		if unguarded[rule.Trigger] {
+			shadowed[i] = true
+			continue
+		}
+		if !rule.Guarded {
+			unguarded[rule.Trigger] = true
+		}
+	}
+	return shadowed
+}
+
+// edges returns the rules of the scene that can fire and lead to a known scene
+func (g *Graph) edges(scene string) []Rule {
+	var edges []Rule
+	shadowed := g.shadowed(scene)
+	for i, rule := range g.Rules[scene] {
+		if !shadowed[i] && rule.Dest != "" {
+			edges = append(edges, rule)
+		}
+	}
+	return edges
+}
+
+// name returns the name of the trigger
+func (g *Graph) name(trigger Trigger) string {
+	if g.Triggers == nil {
+		return trigger.String()
+	}
+	return g.Triggers.NameOf(trigger)
+}
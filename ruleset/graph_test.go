@@ -0,0 +1,98 @@
+package ruleset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const flow = `
+start: menu
+scenes:
+  menu:
+    - {trigger: play, dest: level, transition: {type: fade, duration: 500ms}}
+    - {trigger: options, dest: settings, guard: "!inGame"}
+  level:
+    - {trigger: pause, dest: menu, priority: 1}
+groups:
+  paused:
+    - {trigger: back, dest: level}
+global:
+  - {trigger: quit, dest: credits, except: [credits]}
+tags:
+  settings: [paused]
+`
+
+func newGraph(t *testing.T) *Graph {
+	config, err := ParseYAML([]byte(flow))
+	assert.NoError(t, err)
+	g, err := NewGraph(config, registryWith("play", "options", "pause", "back", "quit"))
+	assert.NoError(t, err)
+	return g
+}
+
+// registryWith returns a registry with the triggers defined
+func registryWith(names ...string) *TriggerRegistry {
+	r := NewTriggerRegistry()
+	for _, name := range names {
+		r.Define(name)
+	}
+	return r
+}
+
+func TestNewGraph(t *testing.T) {
+	g := newGraph(t)
+	assert.Equal(t, "menu", g.Start)
+	assert.Empty(t, g.Current)
+	assert.Equal(t, []string{"credits", "level", "menu", "settings"}, g.Scenes)
+
+	names := func(scene string) []string {
+		var names []string
+		for _, rule := range g.Rules[scene] {
+			names = append(names, g.name(rule.Trigger)+">"+rule.Dest)
+		}
+		return names
+	}
+	assert.Empty(t, names("credits"))
+	assert.Equal(t, []string{"pause>menu", "quit>credits"}, names("level"))
+	assert.Equal(t, []string{"play>level", "options>settings", "quit>credits"}, names("menu"))
+	assert.Equal(t, []string{"back>level", "quit>credits"}, names("settings"))
+
+	play := g.Rules["menu"][0]
+	assert.Equal(t, "fade", play.Transition)
+	assert.NotZero(t, play.Instance)
+	assert.True(t, g.Rules["menu"][1].Guarded)
+
+	// Global directives are the same directive in every scene
+	assert.Equal(t, g.Rules["level"][1].Directive, g.Rules["menu"][2].Directive)
+}
+
+func TestNewGraph_Errors(t *testing.T) {
+	_, err := NewGraph(Config{}, nil)
+	assert.ErrorIs(t, err, ErrUnknownScene)
+
+	_, err = NewGraph(Config{Start: "menu", Scenes: map[string][]DirectiveConfig{
+		"menu": {{Trigger: "jump", Dest: "menu"}},
+	}}, NewTriggerRegistry())
+	assert.ErrorIs(t, err, ErrUnknownTrigger)
+	assert.ErrorContains(t, err, `scene "menu" directive 0`)
+}
+
+func TestConfig_Directives(t *testing.T) {
+	config, err := ParseYAML([]byte(flow))
+	assert.NoError(t, err)
+	var triggers []string
+	for _, c := range config.Directives() {
+		triggers = append(triggers, c.Trigger)
+	}
+	assert.Equal(t, []string{"quit", "pause", "play", "options", "back"}, triggers)
+}
+
+func TestParseJSON(t *testing.T) {
+	config, err := ParseJSON([]byte(`{"start": "menu", "scenes": {"menu": [{"trigger": "loop", "dest": "menu"}]}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "menu", config.Start)
+
+	_, err = ParseJSON([]byte(`{"start": "menu", "scene": {}}`))
+	assert.ErrorContains(t, err, "unknown field")
+}
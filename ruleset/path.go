@@ -0,0 +1,61 @@
+package ruleset
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrNoPath = errors.New("stagehand: no path between the scenes")
+
+// A PathStep is a directive fired on the way between two scenes, scenes are labeled as in the diagrams
+type PathStep struct {
+	From, To   string
+	Trigger    Trigger
+	Transition string // type of the transition, empty if the directive has none
+	Guarded    bool   // whether the step depends on the guard of the directive
+}
+
+// ShortestPath returns the shortest sequence of directives between the scenes with the labels
+func (g *Graph) ShortestPath(from, to string) ([]PathStep, error) {
+	f := g.flowchart()
+	start, end := f.node(from), f.node(to)
+	if start < 0 {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownScene, from)
+	}
+	if end < 0 {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownScene, to)
+	}
+
+	// Breadth-first search keeping the edge each scene was reached by
+	via := make([]int, len(f.labels))
+	for i := range via {
+		via[i] = -1
+	}
+	visited := map[int]bool{start: true}
+	for queue := []int{start}; len(queue) > 0 && !visited[end]; queue = queue[1:] {
+		for i, e := range f.edges {
+			if e.from == queue[0] && !visited[e.to] {
+				visited[e.to] = true
+				via[e.to] = i
+				queue = append(queue, e.to)
+			}
+		}
+	}
+	if !visited[end] {
+		return nil, fmt.Errorf("%w: %q to %q", ErrNoPath, from, to)
+	}
+
+	var path []PathStep
+	for node := end; node != start; {
+		e := f.edges[via[node]]
+		path = append([]PathStep{{
+			From:       f.labels[e.from],
+			To:         f.labels[e.to],
+			Trigger:    e.rule.Trigger,
+			Transition: e.rule.Transition,
+			Guarded:    e.rule.Guarded,
+		}}, path...)
+		node = e.from
+	}
+	return path, nil
+}
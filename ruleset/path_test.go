@@ -0,0 +1,35 @@
+package ruleset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraph_ShortestPath(t *testing.T) {
+	g := newGraph(t)
+	play, _ := g.Triggers.Lookup("play")
+	options, _ := g.Triggers.Lookup("options")
+	back, _ := g.Triggers.Lookup("back")
+
+	path, err := g.ShortestPath("menu", "menu")
+	assert.NoError(t, err)
+	assert.Empty(t, path)
+
+	path, err = g.ShortestPath("menu", "level")
+	assert.NoError(t, err)
+	assert.Equal(t, []PathStep{{From: "menu", To: "level", Trigger: play, Transition: "fade"}}, path)
+
+	g.Rules["menu"] = g.Rules["menu"][1:]
+	path, err = g.ShortestPath("menu", "level")
+	assert.NoError(t, err)
+	assert.Equal(t, []PathStep{
+		{From: "menu", To: "settings", Trigger: options, Guarded: true},
+		{From: "settings", To: "level", Trigger: back},
+	}, path)
+
+	_, err = g.ShortestPath("menu", "boss")
+	assert.ErrorIs(t, err, ErrUnknownScene)
+	_, err = g.ShortestPath("credits", "menu")
+	assert.ErrorIs(t, err, ErrNoPath)
+}
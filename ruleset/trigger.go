@@ -0,0 +1,121 @@
+package ruleset
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+var (
+	ErrUnknownTrigger   = errors.New("stagehand: unknown trigger")
+	ErrDuplicateTrigger = errors.New("stagehand: trigger already registered")
+)
+
+// A Trigger is matched by the directives of a scene to switch to their destination
+type Trigger int
+
+// String returns the name of the trigger in Triggers, or its number if it has no name
+func (t Trigger) String() string {
+	return Triggers.NameOf(t)
+}
+
+// Triggers is the registry used to name triggers in logs, rule sets and debug tools
+var Triggers = NewTriggerRegistry()
+
+// A TriggerRegistry maps names to triggers and back, so triggers can be referenced by name while
+// directives still match them as integers. It's safe for concurrent use
+type TriggerRegistry struct {
+	mu      sync.RWMutex
+	byName  map[string]Trigger
+	byValue map[Trigger]string
+	next    Trigger // next trigger handed out by Define
+}
+
+func NewTriggerRegistry() *TriggerRegistry {
+	return &TriggerRegistry{
+		byName:  make(map[string]Trigger),
+		byValue: make(map[Trigger]string),
+		next:    -1,
+	}
+}
+
+// Define returns the trigger with the name, creating it if needed. Defined triggers are negative, so
+// they never collide with the constants of the game
+func (r *TriggerRegistry) Define(name string) Trigger {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if trigger, ok := r.byName[name]; ok {
+		return trigger
+	}
+	for {
+		trigger := r.next
+		r.next--
+		if _, ok := r.byValue[trigger]; !ok {
+			r.byName[name], r.byValue[trigger] = trigger, name
+			return trigger
+		}
+	}
+}
+
+// Register names an existing trigger, each name and each trigger can only be registered once
+func (r *TriggerRegistry) Register(name string, trigger Trigger) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if existing, ok := r.byName[name]; ok && existing != trigger {
+		return fmt.Errorf("%w: %q", ErrDuplicateTrigger, name)
+	}
+	if existing, ok := r.byValue[trigger]; ok && existing != name {
+		return fmt.Errorf("%w: %d is %q", ErrDuplicateTrigger, trigger, existing)
+	}
+	r.byName[name], r.byValue[trigger] = trigger, name
+	return nil
+}
+
+// Lookup returns the trigger with the name
+func (r *TriggerRegistry) Lookup(name string) (Trigger, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	trigger, ok := r.byName[name]
+	return trigger, ok
+}
+
+// Resolve returns the trigger with the name, unnamed triggers are referenced by their number
+func (r *TriggerRegistry) Resolve(name string) (Trigger, error) {
+	if trigger, ok := r.Lookup(name); ok {
+		return trigger, nil
+	}
+	if n, err := strconv.Atoi(name); err == nil {
+		return Trigger(n), nil
+	}
+	return 0, fmt.Errorf("%w: %q", ErrUnknownTrigger, name)
+}
+
+// Name returns the name of the trigger
+func (r *TriggerRegistry) Name(trigger Trigger) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	name, ok := r.byValue[trigger]
+	return name, ok
+}
+
+// NameOf returns the name of the trigger, or its number if it has no name
+func (r *TriggerRegistry) NameOf(trigger Trigger) string {
+	if name, ok := r.Name(trigger); ok {
+		return name
+	}
+	return strconv.Itoa(int(trigger))
+}
+
+// Names returns the registered names sorted
+func (r *TriggerRegistry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	names := make([]string, 0, len(r.byName))
+	for name := range r.byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
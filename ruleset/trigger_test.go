@@ -0,0 +1,66 @@
+package ruleset
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTriggerRegistry_Define(t *testing.T) {
+	r := NewTriggerRegistry()
+	assert.NoError(t, r.Register("taken", -1))
+
+	open := r.Define("settings.open")
+	assert.Equal(t, Trigger(-2), open)
+	assert.Equal(t, open, r.Define("settings.open"))
+	assert.NotEqual(t, open, r.Define("settings.close"))
+
+	trigger, ok := r.Lookup("settings.open")
+	assert.True(t, ok)
+	assert.Equal(t, open, trigger)
+	name, ok := r.Name(open)
+	assert.True(t, ok)
+	assert.Equal(t, "settings.open", name)
+	assert.Equal(t, []string{"settings.close", "settings.open", "taken"}, r.Names())
+}
+
+func TestTriggerRegistry_Register(t *testing.T) {
+	r := NewTriggerRegistry()
+	assert.NoError(t, r.Register("play", 1))
+	assert.NoError(t, r.Register("play", 1))
+	assert.ErrorIs(t, r.Register("play", 2), ErrDuplicateTrigger)
+	assert.ErrorIs(t, r.Register("start", 1), ErrDuplicateTrigger)
+
+	_, ok := r.Lookup("quit")
+	assert.False(t, ok)
+}
+
+func TestTriggerRegistry_Resolve(t *testing.T) {
+	r := NewTriggerRegistry()
+	play := r.Define("play")
+
+	trigger, err := r.Resolve("play")
+	assert.NoError(t, err)
+	assert.Equal(t, play, trigger)
+	trigger, err = r.Resolve("3")
+	assert.NoError(t, err)
+	assert.Equal(t, Trigger(3), trigger)
+	_, err = r.Resolve("quit")
+	assert.ErrorIs(t, err, ErrUnknownTrigger)
+
+	assert.Equal(t, "play", r.NameOf(play))
+	assert.Equal(t, "3", r.NameOf(3))
+	assert.Equal(t, "3", fmt.Sprint(Trigger(3)))
+}
+
+func TestTrigger_String(t *testing.T) {
+	saved := Triggers
+	Triggers = NewTriggerRegistry()
+	t.Cleanup(func() { Triggers = saved })
+
+	trigger := Triggers.Define("string")
+	assert.Equal(t, "string", trigger.String())
+	assert.Equal(t, "string", fmt.Sprint(trigger))
+	assert.Equal(t, "12345", Trigger(12345).String())
+}
@@ -0,0 +1,118 @@
+package ruleset
+
+import (
+	"fmt"
+	"sort"
+)
+
+// An IssueKind classifies the issues found by Validate
+type IssueKind int
+
+const (
+	// UnreachableScene is a scene no directive leads to from the start scene
+	UnreachableScene IssueKind = iota
+	// DeadEndScene is a scene without directives to leave it
+	DeadEndScene
+	// TriggerConflict is a directive that never fires because an earlier directive of the scene
+	// always matches the same trigger
+	TriggerConflict
+	// MissingDestination is a directive without a destination or with an unregistered DestID
+	MissingDestination
+	// SharedTransition is a transition used by more than one directive, it's restarted while it's
+	// still running if they fire back to back
+	SharedTransition
+)
+
+func (k IssueKind) String() string {
+	switch k {
+	case UnreachableScene:
+		return "unreachable scene"
+	case DeadEndScene:
+		return "dead end"
+	case TriggerConflict:
+		return "trigger conflict"
+	case MissingDestination:
+		return "missing destination"
+	case SharedTransition:
+		return "shared transition"
+	}
+	return fmt.Sprintf("IssueKind(%d)", int(k))
+}
+
+// An Issue is a problem found in the rules of a SceneDirector
+type Issue struct {
+	Kind    IssueKind
+	Scene   string // label of the scene
+	Message string
+}
+
+func (i Issue) String() string {
+	return fmt.Sprintf("%s: %s: %s", i.Scene, i.Kind, i.Message)
+}
+
+// Validate analyzes the flow from the start scene and returns the issues found sorted by kind and scene
+func (g *Graph) Validate() []Issue {
+	var issues []Issue
+	report := func(kind IssueKind, scene string, format string, args ...any) {
+		issues = append(issues, Issue{Kind: kind, Scene: scene, Message: fmt.Sprintf(format, args...)})
+	}
+
+	reachable := map[string]bool{g.Start: true}
+	for queue := []string{g.Start}; len(queue) > 0; queue = queue[1:] {
+		for _, rule := range g.edges(queue[0]) {
+			if !reachable[rule.Dest] {
+				reachable[rule.Dest] = true
+				queue = append(queue, rule.Dest)
+			}
+		}
+	}
+
+	users := make(map[int]map[int]bool) // directives using each transition
+	for _, scene := range g.Scenes {
+		if !reachable[scene] {
+			report(UnreachableScene, scene, "no directive leads here from %s", g.Start)
+		}
+		if len(g.Rules[scene]) == 0 {
+			report(DeadEndScene, scene, "no directive leaves this scene")
+		}
+
+		shadowed := g.shadowed(scene)
+		for i, rule := range g.Rules[scene] {
+			if shadowed[i] {
+				report(TriggerConflict, scene, "directive for trigger %s never fires, an earlier one always matches", g.name(rule.Trigger))
+			}
+			if rule.Dest == "" {
+				if rule.DestID != "" {
+					report(MissingDestination, scene, "directive for trigger %s leads to unregistered scene %q", g.name(rule.Trigger), rule.DestID)
+				} else {
+					report(MissingDestination, scene, "directive for trigger %s has no destination", g.name(rule.Trigger))
+				}
+			}
+			if rule.Instance != 0 {
+				if users[rule.Instance] == nil {
+					users[rule.Instance] = make(map[int]bool)
+				}
+				users[rule.Instance][rule.Directive] = true
+			}
+		}
+	}
+
+	// Shared transitions are reported once, at the first scene using them
+	reported := make(map[int]bool)
+	for _, scene := range g.Scenes {
+		for _, rule := range g.Rules[scene] {
+			if rule.Instance != 0 && len(users[rule.Instance]) > 1 && !reported[rule.Instance] {
+				reported[rule.Instance] = true
+				report(SharedTransition, scene, "transition %s of the directive for trigger %s is used by %d directives", rule.Transition, g.name(rule.Trigger), len(users[rule.Instance]))
+			}
+		}
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].Kind != issues[j].Kind {
+			return issues[i].Kind < issues[j].Kind
+		}
+		return issues[i].Scene < issues[j].Scene
+	})
+	return issues
+}
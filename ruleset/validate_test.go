@@ -0,0 +1,48 @@
+package ruleset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraph_Validate(t *testing.T) {
+	g := &Graph{
+		Start:  "menu",
+		Scenes: []string{"credits", "level", "menu", "secret"},
+		Rules: map[string][]Rule{
+			"menu": {
+				{Trigger: 1, Dest: "level", Transition: "FadeTransition", Directive: 1, Instance: 1},
+				{Trigger: 1, Dest: "credits", Directive: 2},
+				{Trigger: 2, DestID: "missing", Directive: 3},
+				{Trigger: 3, Directive: 4},
+			},
+			"level":  {{Trigger: 4, Dest: "menu", Transition: "FadeTransition", Directive: 5, Instance: 1}},
+			"secret": {{Trigger: 4, Dest: "menu", Directive: 6}},
+		},
+		Triggers: NewTriggerRegistry(),
+	}
+
+	kinds := make(map[IssueKind][]string)
+	for _, issue := range g.Validate() {
+		kinds[issue.Kind] = append(kinds[issue.Kind], issue.Scene)
+	}
+	assert.Equal(t, []string{"credits", "secret"}, kinds[UnreachableScene])
+	assert.Equal(t, []string{"credits"}, kinds[DeadEndScene])
+	assert.Equal(t, []string{"menu"}, kinds[TriggerConflict])
+	assert.Equal(t, []string{"menu", "menu"}, kinds[MissingDestination])
+	assert.Equal(t, []string{"level"}, kinds[SharedTransition])
+}
+
+func TestGraph_ValidateConfig(t *testing.T) {
+	// Group and global directives aren't shared transitions, every directive gets its own
+	g := newGraph(t)
+	assert.Empty(t, g.Validate()[1:])
+	assert.Equal(t, Issue{Kind: DeadEndScene, Scene: "credits", Message: "no directive leaves this scene"}, g.Validate()[0])
+}
+
+func TestIssue_String(t *testing.T) {
+	issue := Issue{Kind: DeadEndScene, Scene: "credits", Message: "no directive leaves this scene"}
+	assert.Equal(t, "credits: dead end: no directive leaves this scene", issue.String())
+	assert.Equal(t, "IssueKind(9)", IssueKind(9).String())
+}
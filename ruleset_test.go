@@ -0,0 +1,237 @@
+package stagehand
+
+import (
+	"image/color"
+	"testing"
+	"time"
+
+	"github.com/joelschutz/stagehand/ruleset"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRuleSetLoader() (*RuleSetLoader[int], *Registry[int]) {
+	r := NewRegistry[int]()
+	r.RegisterSingleton("menu", func() Scene[int] { return &MockScene{} })
+	r.Register("level", func() Scene[int] { return &MockScene{} })
+	l := NewRuleSetLoader[int](r)
+	l.SetTriggerRegistry(NewTriggerRegistry())
+	l.RegisterTrigger("play", 1)
+	l.RegisterTrigger("quit", 2)
+	return l, r
+}
+
+func TestRuleSetLoader_LoadJSON(t *testing.T) {
+	l, r := newRuleSetLoader()
+	named := l.TriggerRegistry().Define("named")
+	director, err := l.LoadJSON([]byte(`{
+		"start": "menu",
+		"scenes": {
+			"menu": [{"trigger": "play", "dest": "level", "transition": {"type": "fade", "duration": "500ms", "easing": "outCubic"}}],
+			"level": [{"trigger": "quit", "dest": "menu"}, {"trigger": "3", "dest": "level"}, {"trigger": "named", "dest": "menu"}]
+		}
+	}`), 0)
+	assert.NoError(t, err)
+	menu, _ := r.Resolve("menu")
+	assert.Same(t, menu, director.current)
+
+	directives := director.IDRuleSet["menu"]
+	assert.Len(t, directives, 1)
+	assert.Equal(t, SceneTransitionTrigger(1), directives[0].Trigger)
+	assert.Equal(t, SceneID("level"), directives[0].DestID)
+	fade, ok := directives[0].Transition.(*FadeTransition[int])
+	assert.True(t, ok)
+	assert.Equal(t, EaseOutCubic(.5), fade.Ease(.5))
+
+	assert.Nil(t, director.IDRuleSet["level"][0].Transition)
+	assert.Equal(t, named, director.IDRuleSet["level"][2].Trigger)
+	assert.Equal(t, SceneTransitionTrigger(3), director.IDRuleSet["level"][1].Trigger)
+
+	director.ProcessTrigger(1)
+	assert.Same(t, fade, director.current)
+
+	// The director names its triggers with the registry of the loader
+	assert.Same(t, l.TriggerRegistry(), director.TriggerRegistry())
+	assert.ErrorIs(t, l.RegisterTrigger("play", 3), ErrDuplicateTrigger)
+}
+
+func TestRuleSetLoader_LoadYAML(t *testing.T) {
+	l, _ := newRuleSetLoader()
+	director, err := l.LoadYAML([]byte(`
+start: menu
+scenes:
+  menu:
+    - trigger: play
+      dest: level
+      transition:
+        type: slide
+        direction: top-to-bottom
+        mode: cover
+        ticks: 30
+  level:
+    - trigger: quit
+      dest: menu
+`), 0)
+	assert.NoError(t, err)
+	slide, ok := director.IDRuleSet["menu"][0].Transition.(*SlideTransition[int])
+	assert.True(t, ok)
+	assert.Equal(t, SlideCover, slide.Mode())
+	assert.Equal(t, 1., slide.dy)
+
+	director.ProcessTrigger(1)
+	id, _ := director.CurrentID()
+	assert.Equal(t, SceneID(""), id)
+	slide.End()
+	id, _ = director.CurrentID()
+	assert.Equal(t, SceneID("level"), id)
+}
+
+func TestRuleSetLoader_Errors(t *testing.T) {
+	l, _ := newRuleSetLoader()
+	for name, tc := range map[string]struct {
+		config string
+		err    error
+	}{
+		"unknown scene":      {`{"start": "menu", "scenes": {"boss": []}}`, ErrUnknownScene},
+		"unknown dest":       {`{"start": "menu", "scenes": {"menu": [{"trigger": "play", "dest": "boss"}]}}`, ErrUnknownScene},
+		"unknown start":      {`{"start": "boss"}`, ErrUnknownScene},
+		"unknown trigger":    {`{"start": "menu", "scenes": {"menu": [{"trigger": "jump", "dest": "level"}]}}`, ErrUnknownTrigger},
+		"unknown transition": {`{"start": "menu", "scenes": {"menu": [{"trigger": "play", "dest": "level", "transition": {"type": "spin"}}]}}`, ErrUnknownTransition},
+		"missing timeline":   {`{"start": "menu", "scenes": {"menu": [{"trigger": "play", "dest": "level", "transition": {"type": "fade"}}]}}`, ErrInvalidParam},
+		"unknown guard":      {`{"start": "menu", "scenes": {"menu": [{"trigger": "play", "dest": "level", "guard": "!ready"}]}}`, ErrUnknownGuard},
+		"invalid easing":     {`{"start": "menu", "scenes": {"menu": [{"trigger": "play", "dest": "level", "transition": {"type": "fade", "factor": 0.1, "easing": "wobble"}}]}}`, ErrInvalidParam},
+	} {
+		_, err := l.LoadJSON([]byte(tc.config), 0)
+		assert.ErrorIs(t, err, tc.err, name)
+	}
+
+	_, err := l.LoadJSON([]byte(`{"start": "menu", "scene": {}}`), 0)
+	assert.ErrorContains(t, err, "unknown field")
+}
+
+func TestRuleSetLoader_RegisterTransition(t *testing.T) {
+	l, _ := newRuleSetLoader()
+	trans := &baseTransitionImplementation{}
+	l.RegisterTransition("Custom", func(params TransitionParams) (SceneTransition[int], error) {
+		return trans, nil
+	})
+	got, err := l.Transition(TransitionParams{"type": "custom"})
+	assert.NoError(t, err)
+	assert.Same(t, trans, got)
+}
+
+func TestRuleSetLoader_BuiltinTransitions(t *testing.T) {
+	l, _ := newRuleSetLoader()
+	for _, params := range []TransitionParams{
+		{"type": "fade", "out": "200ms", "hold": "100ms", "in": "200ms", "color": "#ffffff"},
+		{"type": "crossfade", "factor": .1},
+		{"type": "slide", "angle": 45, "parallax": .5, "duration": "1s"},
+		{"type": "dissolve", "duration": "1s", "uniforms": map[string]any{"Scale": 12, "Offset": []any{1, 2.5}}},
+		{"type": "radialWipe", "duration": "1s"},
+		{"type": "ripple", "duration": "1s"},
+		{"type": "pixelsort", "duration": "1s"},
+		{"type": "linearmask", "duration": "1s", "angle": 90, "softness": .2, "inverted": true},
+		{"type": "radialmask", "duration": "1s"},
+		{"type": "iris", "duration": "1s", "shape": "star", "points": 6, "mode": "close"},
+	} {
+		trans, err := l.Transition(params)
+		assert.NoError(t, err, params.Type())
+		assert.NotNil(t, trans, params.Type())
+	}
+
+	// The points are only read for a star, which needs at least three
+	for _, points := range []any{-1, 1, "five"} {
+		_, err := l.Transition(TransitionParams{"type": "iris", "duration": "1s", "shape": "star", "points": points})
+		assert.ErrorIs(t, err, ErrInvalidParam, points)
+	}
+	_, err := l.Transition(TransitionParams{"type": "iris", "duration": "1s", "shape": "circle", "points": -1})
+	assert.NoError(t, err)
+
+	// The command-line tool accepts the types listed in the ruleset package
+	var types []string
+	for name := range builtinTransitions[int]() {
+		types = append(types, name)
+	}
+	assert.ElementsMatch(t, ruleset.TransitionTypes, types)
+}
+
+func TestTransitionParams(t *testing.T) {
+	p := TransitionParams{"duration": "1.5s", "color": "#ff000080", "ticks": "ten", "mode": "sideways"}
+
+	d, err := p.Duration("duration", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 1500*time.Millisecond, d)
+
+	c, err := p.Color("color", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, color.NRGBA{R: 0xff, A: 0x80}, c)
+
+	c, err = p.Color("missing", color.White)
+	assert.NoError(t, err)
+	assert.Equal(t, color.White, c)
+
+	_, err = p.Float("ticks", 0)
+	assert.ErrorIs(t, err, ErrInvalidParam)
+
+	_, err = choice(p, "mode", SlidePush, map[string]SlideMode{"push": SlidePush})
+	assert.ErrorIs(t, err, ErrInvalidParam)
+}
+
+func TestEasingByName(t *testing.T) {
+	for _, name := range []string{"outCubic", "easeOutCubic", "ease-out-cubic", "OUT_CUBIC"} {
+		easing, ok := EasingByName(name)
+		assert.True(t, ok, name)
+		assert.Equal(t, EaseOutCubic(.3), easing(.3), name)
+	}
+	_, ok := EasingByName("wobble")
+	assert.False(t, ok)
+}
+
+func TestRuleSetLoader_Guard(t *testing.T) {
+	l, _ := newRuleSetLoader()
+	l.RegisterGuard("tutorialDone", func(state int) bool { return state > 0 })
+	director, err := l.LoadJSON([]byte(`{
+		"start": "menu",
+		"scenes": {"menu": [
+			{"trigger": "play", "dest": "level", "guard": "tutorialDone"},
+			{"trigger": "play", "dest": "menu", "guard": "!tutorialDone"}
+		]}
+	}`), 1)
+	assert.NoError(t, err)
+	directives := director.IDRuleSet["menu"]
+	assert.True(t, directives[0].Guard(1))
+	assert.False(t, directives[1].Guard(1))
+
+	director.ProcessTrigger(1)
+	id, _ := director.CurrentID()
+	assert.Equal(t, SceneID("level"), id)
+}
+
+func TestRuleSetLoader_GroupsAndGlobal(t *testing.T) {
+	l, r := newRuleSetLoader()
+	r.RegisterSingleton("settings", func() Scene[int] { return &MockScene{} })
+	l.RegisterTrigger("settings", 4)
+	director, err := l.LoadYAML([]byte(`
+start: level
+tags:
+  level: [gameplay]
+groups:
+  gameplay:
+    - {trigger: quit, dest: menu}
+global:
+  - {trigger: settings, dest: settings, except: [settings], priority: 1}
+`), 0)
+	assert.NoError(t, err)
+	assert.Len(t, director.GroupRuleSet["gameplay"], 1)
+	assert.Equal(t, []string{"settings"}, director.GlobalRuleSet[0].Except)
+	assert.Equal(t, 1, director.GlobalRuleSet[0].Priority)
+	assert.Equal(t, []string{"gameplay"}, director.IDGroups["level"])
+
+	director.ProcessTrigger(2)
+	id, _ := director.CurrentID()
+	assert.Equal(t, SceneID("menu"), id)
+
+	_, err = l.LoadYAML([]byte("start: menu\ntags: {boss: [gameplay]}"), 0)
+	assert.ErrorIs(t, err, ErrUnknownScene)
+	_, err = l.LoadYAML([]byte("start: menu\nglobal: [{trigger: jump, dest: menu}]"), 0)
+	assert.ErrorIs(t, err, ErrUnknownTrigger)
+}
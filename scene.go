@@ -24,3 +24,15 @@ type TransitionAwareScene[T any] interface {
 	PreTransition(Scene[T]) T   // Runs before new scene is loaded, must return last state
 	PostTransition(T, Scene[T]) // Runs when old scene is unloaded
 }
+
+// A StatefulScene exposes its current state, so it can be read without unloading the scene
+type StatefulScene[T any] interface {
+	Scene[T]
+	State() T
+}
+
+// A PayloadScene receives the payload of the trigger that switched to it, before it's loaded
+type PayloadScene[T any] interface {
+	Scene[T]
+	ReceivePayload(payload any)
+}
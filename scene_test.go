@@ -14,10 +14,12 @@ type MockScene struct {
 	drawCalled    bool
 	layoutCalled  bool
 	unloadReturns int
+	sm            SceneController[int] // controller the scene was last loaded with
 }
 
 func (m *MockScene) Load(state int, sm SceneController[int]) {
 	m.loadCalled = true
+	m.sm = sm
 	m.unloadReturns = state
 }
 
@@ -0,0 +1,156 @@
+package stagehand
+
+import (
+	_ "embed"
+	"sync"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// Bundled Kage shaders for ShaderTransition
+var (
+	//go:embed shaders/dissolve.kage
+	DissolveShader []byte
+	//go:embed shaders/radialwipe.kage
+	RadialWipeShader []byte
+	//go:embed shaders/ripple.kage
+	RippleShader []byte
+	//go:embed shaders/pixelsort.kage
+	PixelSortShader []byte
+)
+
+// Compiled shaders are shared between transitions with the same source
+var shaderCache sync.Map
+
+// A ShaderTransition draws the transition with a Kage shader. The origin scene is the source image 0
+// and the destination scene is the source image 1. The shader receives the uniforms:
+//
+//	var Progress float   // eased progress of the transition, from 0 to 1
+//	var Time float       // seconds since the transition started
+//	var Resolution vec2  // size of the screen in pixels
+//
+// plus any parameter set with SetUniform.
+type ShaderTransition[T any] struct {
+	BaseTransition[T]
+	shader       *ebiten.Shader
+	uniforms     map[string]any
+	shaderOp     ebiten.DrawRectShaderOptions
+	startTime    time.Time
+	frameUpdated bool
+}
+
+// NewShaderTransition compiles the Kage source and returns a transition driven by the timeline
+func NewShaderTransition[T any](src []byte, timeline *Timeline, opts ...TransitionOption) (*ShaderTransition[T], error) {
+	shader, err := compileShader(src)
+	if err != nil {
+		return nil, err
+	}
+	t := &ShaderTransition[T]{
+		shader:   shader,
+		uniforms: map[string]any{},
+	}
+	t.SetTimeline(timeline)
+	t.SetOptions(opts...)
+	return t, nil
+}
+
+func compileShader(src []byte) (*ebiten.Shader, error) {
+	if shader, ok := shaderCache.Load(string(src)); ok {
+		return shader.(*ebiten.Shader), nil
+	}
+	shader, err := ebiten.NewShader(src)
+	if err != nil {
+		return nil, err
+	}
+	shaderCache.Store(string(src), shader)
+	return shader, nil
+}
+
+// mustShaderTransition is used by the bundled shaders, which are known to compile
+func mustShaderTransition[T any](src []byte, timeline *Timeline, opts ...TransitionOption) *ShaderTransition[T] {
+	t, err := NewShaderTransition[T](src, timeline, opts...)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// SetUniform sets a user-defined uniform, the value must be a numeric type or a slice of them. Float
+// uniforms must be set with floats, ints are passed with their bits unchanged and read as garbage
+func (t *ShaderTransition[T]) SetUniform(name string, value any) *ShaderTransition[T] {
+	t.uniforms[name] = value
+	return t
+}
+
+// Start starts the transition from the given "from" scene to the given "to" scene
+func (t *ShaderTransition[T]) Start(fromScene, toScene Scene[T], sm SceneController[T]) {
+	t.BaseTransition.Start(fromScene, toScene, sm)
+	t.frameUpdated = false
+	t.startTime = Clock.Now()
+}
+
+// Update updates the transition state
+func (t *ShaderTransition[T]) Update() error {
+	if !t.frameUpdated {
+		if t.Advance() {
+			t.End()
+		}
+		t.frameUpdated = true
+	}
+
+	// Update the scenes
+	return t.UpdateScenes()
+}
+
+// Draw draws the transition effect
+func (t *ShaderTransition[T]) Draw(screen *ebiten.Image) {
+	toImg, fromImg := t.PreDraw(screen.Bounds())
+	w, h := screen.Bounds().Dx(), screen.Bounds().Dy()
+
+	t.uniforms["Progress"] = float32(t.Ease(t.Progress()))
+	t.uniforms["Time"] = float32(Clock.Since(t.startTime).Seconds())
+	if res, ok := t.uniforms["Resolution"].([]float32); ok && len(res) == 2 {
+		res[0], res[1] = float32(w), float32(h)
+	} else {
+		t.uniforms["Resolution"] = []float32{float32(w), float32(h)}
+	}
+	t.shaderOp.Uniforms = t.uniforms
+	t.shaderOp.Images = [4]*ebiten.Image{fromImg, toImg}
+	screen.DrawRectShader(w, h, t.shader, &t.shaderOp)
+	t.frameUpdated = false
+}
+
+// End releases the scene images and ends the transition
+func (t *ShaderTransition[T]) End() {
+	t.shaderOp.Images = [4]*ebiten.Image{}
+	t.BaseTransition.End()
+}
+
+// NewDissolveTransition returns a transition that dissolves the scenes following a noise pattern
+func NewDissolveTransition[T any](timeline *Timeline, opts ...TransitionOption) *ShaderTransition[T] {
+	return mustShaderTransition[T](DissolveShader, timeline, opts...).
+		SetUniform("Scale", float32(24)).
+		SetUniform("Softness", float32(.1))
+}
+
+// NewRadialWipeTransition returns a transition that reveals the destination like a clock hand
+func NewRadialWipeTransition[T any](timeline *Timeline, opts ...TransitionOption) *ShaderTransition[T] {
+	return mustShaderTransition[T](RadialWipeShader, timeline, opts...).
+		SetUniform("Softness", float32(.02))
+}
+
+// NewRippleTransition returns a transition that blends the scenes while distorting them with waves
+func NewRippleTransition[T any](timeline *Timeline, opts ...TransitionOption) *ShaderTransition[T] {
+	return mustShaderTransition[T](RippleShader, timeline, opts...).
+		SetUniform("Amplitude", float32(.03)).
+		SetUniform("Frequency", float32(8)).
+		SetUniform("Speed", float32(10))
+}
+
+// NewPixelSortTransition returns a glitch transition where columns of pixels fall at random times
+func NewPixelSortTransition[T any](timeline *Timeline, opts ...TransitionOption) *ShaderTransition[T] {
+	return mustShaderTransition[T](PixelSortShader, timeline, opts...).
+		SetUniform("Width", float32(4)).
+		SetUniform("Spread", float32(.5))
+}
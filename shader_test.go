@@ -0,0 +1,90 @@
+package stagehand
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShaderTransition_BundledShaders(t *testing.T) {
+	shaders := map[string][]byte{
+		"dissolve":   DissolveShader,
+		"radialwipe": RadialWipeShader,
+		"ripple":     RippleShader,
+		"pixelsort":  PixelSortShader,
+	}
+	for name, src := range shaders {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewShaderTransition[int](src, NewFactorTimeline(.5))
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestShaderTransition_InvalidShader(t *testing.T) {
+	trans, err := NewShaderTransition[int]([]byte("package main\nfunc Fragment("), NewFactorTimeline(.5))
+	assert.Error(t, err)
+	assert.Nil(t, trans)
+}
+
+func TestShaderTransition_Update(t *testing.T) {
+	from := &MockScene{}
+	to := &MockScene{}
+	trans := NewDissolveTransition[int](NewFactorTimeline(.5))
+	sm := NewSceneManager[int](from, 0)
+	sm.SwitchWithTransition(to, trans)
+
+	err := sm.Update()
+	assert.NoError(t, err)
+	assert.Equal(t, .5, trans.Progress())
+	assert.True(t, trans.frameUpdated)
+
+	// Only updates once per frame
+	err = sm.Update()
+	assert.NoError(t, err)
+	assert.Equal(t, .5, trans.Progress())
+
+	trans.Draw(ebiten.NewImage(100, 100))
+	assert.False(t, trans.frameUpdated)
+	assert.Equal(t, float32(.5), trans.uniforms["Progress"])
+	assert.Equal(t, []float32{100, 100}, trans.uniforms["Resolution"])
+	assert.Equal(t, float32(24), trans.uniforms["Scale"])
+
+	err = sm.Update()
+	assert.NoError(t, err)
+	assert.Equal(t, to, sm.current)
+}
+
+func TestShaderTransition_Draw(t *testing.T) {
+	from := &MockScene{}
+	to := &MockScene{}
+	transitions := []*ShaderTransition[int]{
+		NewDissolveTransition[int](NewFactorTimeline(.5)),
+		NewRadialWipeTransition[int](NewFactorTimeline(.5)),
+		NewRippleTransition[int](NewFactorTimeline(.5), WithEasing(EaseInOutSine)),
+		NewPixelSortTransition[int](NewFactorTimeline(.5)),
+	}
+	for _, trans := range transitions {
+		trans.Start(from, to, nil)
+		trans.Update()
+		trans.Draw(ebiten.NewImage(100, 100))
+		assert.True(t, from.drawCalled)
+		assert.True(t, to.drawCalled)
+	}
+}
+
+func TestShaderTransition_FloatUniforms(t *testing.T) {
+	transitions := []*ShaderTransition[int]{
+		NewDissolveTransition[int](NewFactorTimeline(.5)),
+		NewRadialWipeTransition[int](NewFactorTimeline(.5)),
+		NewRippleTransition[int](NewFactorTimeline(.5)),
+		NewPixelSortTransition[int](NewFactorTimeline(.5)),
+	}
+	// The bundled shaders only declare float uniforms
+	for _, trans := range transitions {
+		for name, value := range trans.uniforms {
+			assert.IsType(t, float32(0), value, name)
+		}
+	}
+}
@@ -0,0 +1,252 @@
+package stagehand
+
+import ebiten "github.com/hajimehoshi/ebiten/v2"
+
+// A StackedScene is a Scene that keeps running while other scenes are pushed on top of it
+type StackedScene[T any] interface {
+	Scene[T]
+	DrawCovered() bool   // Whether the scene is still drawn while covered
+	UpdateCovered() bool // Whether the scene is still updated while covered
+}
+
+// A SceneStack is a SceneController that keeps a stack of scenes, only the top one receives input
+// while the ones underneath can be drawn and updated as a backdrop
+type SceneStack[T any] struct {
+	scenes     []Scene[T]
+	transition SceneTransition[T]
+	base       int  // number of scenes underneath the running transition
+	pushing    bool // whether the origin of the running transition stays in the stack
+	onRemove   map[Scene[T]]func()
+}
+
+func NewSceneStack[T any](scene Scene[T], state T) *SceneStack[T] {
+	s := &SceneStack[T]{scenes: []Scene[T]{scene}}
+	scene.Load(state, s)
+	return s
+}
+
+// Top returns the scene on top of the stack
+func (s *SceneStack[T]) Top() Scene[T] {
+	return s.scenes[len(s.scenes)-1]
+}
+
+// Len returns the number of scenes in the stack
+func (s *SceneStack[T]) Len() int {
+	return len(s.scenes)
+}
+
+// Push covers the top scene with a new one
+func (s *SceneStack[T]) Push(scene Scene[T]) {
+	s.endTransition()
+	scene.Load(s.cover(s.Top(), scene), s)
+	s.scenes = append(s.scenes, scene)
+}
+
+func (s *SceneStack[T]) PushWithTransition(scene Scene[T], transition SceneTransition[T]) {
+	s.endTransition()
+	top := s.Top()
+	transition.Start(top, scene, s)
+	scene.Load(s.cover(top, scene), s)
+	s.scenes = append(s.scenes, scene)
+	s.startTransition(transition, len(s.scenes)-2, true)
+}
+
+// Pop discards the top scene and returns to the one underneath, the last scene is never popped
+func (s *SceneStack[T]) Pop() {
+	s.endTransition()
+	if len(s.scenes) < 2 {
+		return
+	}
+	top := s.Top()
+	s.scenes = s.scenes[:len(s.scenes)-1]
+	s.reveal(s.Top(), top)
+}
+
+func (s *SceneStack[T]) PopWithTransition(transition SceneTransition[T]) {
+	s.endTransition()
+	if len(s.scenes) < 2 {
+		return
+	}
+	top := s.Top()
+	s.scenes = s.scenes[:len(s.scenes)-1]
+	transition.Start(top, s.Top(), s)
+	s.startTransition(transition, len(s.scenes)-1, false)
+}
+
+// PopTo discards every scene above the given one, nothing happens if the scene is not in the stack
+func (s *SceneStack[T]) PopTo(scene Scene[T]) {
+	s.endTransition()
+	i := s.indexOf(scene)
+	if i < 0 || i == len(s.scenes)-1 {
+		return
+	}
+	top := s.Top()
+	s.discard(i+1, len(s.scenes)-1)
+	s.reveal(scene, top)
+}
+
+func (s *SceneStack[T]) PopToWithTransition(scene Scene[T], transition SceneTransition[T]) {
+	s.endTransition()
+	i := s.indexOf(scene)
+	if i < 0 || i == len(s.scenes)-1 {
+		return
+	}
+	top := s.Top()
+	s.discard(i+1, len(s.scenes)-1)
+	transition.Start(top, scene, s)
+	s.startTransition(transition, i, false)
+}
+
+// Replace swaps the top scene for a new one, just like SceneManager.SwitchTo
+func (s *SceneStack[T]) Replace(scene Scene[T]) {
+	s.endTransition()
+	top := s.Top()
+	scene.Load(s.unload(top), s)
+	s.scenes[len(s.scenes)-1] = scene
+}
+
+func (s *SceneStack[T]) ReplaceWithTransition(scene Scene[T], transition SceneTransition[T]) {
+	s.endTransition()
+	top := s.Top()
+	transition.Start(top, scene, s)
+	if c, ok := top.(TransitionAwareScene[T]); ok {
+		scene.Load(c.PreTransition(scene), s)
+	} else {
+		scene.Load(top.Unload(), s)
+	}
+	s.scenes[len(s.scenes)-1] = scene
+	s.startTransition(transition, len(s.scenes)-1, false)
+}
+
+func (s *SceneStack[T]) ReturnFromTransition(scene, origin Scene[T]) {
+	s.transition = nil
+	if s.pushing {
+		// Origin was covered, it stays in the stack untouched
+		return
+	}
+	s.reveal(scene, origin)
+}
+
+// cover returns the state handed to a scene pushed over the given one
+func (s *SceneStack[T]) cover(top, scene Scene[T]) T {
+	if c, ok := top.(TransitionAwareScene[T]); ok {
+		return c.PreTransition(scene)
+	}
+	return top.Unload()
+}
+
+// reveal hands the state of the discarded origin to the scene underneath
+func (s *SceneStack[T]) reveal(scene, origin Scene[T]) {
+	if c, ok := scene.(TransitionAwareScene[T]); ok {
+		c.PostTransition(s.unload(origin), origin)
+	} else {
+		scene.Load(s.unload(origin), s)
+	}
+}
+
+// unload unloads a scene that is leaving the stack
+func (s *SceneStack[T]) unload(scene Scene[T]) T {
+	state := scene.Unload()
+	s.remove(scene)
+	return state
+}
+
+// remove runs the function watching a scene that left the stack
+func (s *SceneStack[T]) remove(scene Scene[T]) {
+	if fn, ok := s.onRemove[scene]; ok {
+		delete(s.onRemove, scene)
+		fn()
+	}
+}
+
+// watch registers a function to run once the scene leaves the stack
+func (s *SceneStack[T]) watch(scene Scene[T], fn func()) {
+	if s.onRemove == nil {
+		s.onRemove = make(map[Scene[T]]func())
+	}
+	s.onRemove[scene] = fn
+}
+
+// discard unloads the scenes between the indexes, the scene at the last index is removed but not
+// unloaded as its state is still needed. Covered scenes were already unloaded when covered, unless they
+// are a TransitionAwareScene
+func (s *SceneStack[T]) discard(from, to int) {
+	for i := to - 1; i >= from; i-- {
+		if _, ok := s.scenes[i].(TransitionAwareScene[T]); ok {
+			s.unload(s.scenes[i])
+		} else {
+			s.remove(s.scenes[i])
+		}
+	}
+	s.scenes = s.scenes[:from]
+}
+
+func (s *SceneStack[T]) indexOf(scene Scene[T]) int {
+	for i, sc := range s.scenes {
+		if sc == scene {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *SceneStack[T]) startTransition(transition SceneTransition[T], base int, pushing bool) {
+	s.transition = transition
+	s.base = base
+	s.pushing = pushing
+}
+
+func (s *SceneStack[T]) endTransition() {
+	if s.transition != nil {
+		// previous transition is still running, end it first
+		s.transition.End()
+	}
+}
+
+// top returns the running transition or the top scene and how many scenes are underneath it
+func (s *SceneStack[T]) top() (ProtoScene[T], int) {
+	if s.transition != nil {
+		return s.transition, s.base
+	}
+	return s.Top(), len(s.scenes) - 1
+}
+
+// lowest returns the index of the lowest covered scene that still matches the predicate
+func (s *SceneStack[T]) lowest(base int, predicate func(StackedScene[T]) bool) int {
+	i := base
+	for i > 0 {
+		c, ok := s.scenes[i-1].(StackedScene[T])
+		if !ok || !predicate(c) {
+			break
+		}
+		i--
+	}
+	return i
+}
+
+// Ebiten Interface
+func (s *SceneStack[T]) Update() error {
+	top, base := s.top()
+	for _, sc := range s.scenes[s.lowest(base, StackedScene[T].UpdateCovered):base] {
+		if err := sc.Update(); err != nil {
+			return err
+		}
+	}
+	return top.Update()
+}
+
+func (s *SceneStack[T]) Draw(screen *ebiten.Image) {
+	top, base := s.top()
+	for _, sc := range s.scenes[s.lowest(base, StackedScene[T].DrawCovered):base] {
+		sc.Draw(screen)
+	}
+	top.Draw(screen)
+}
+
+func (s *SceneStack[T]) Layout(w, h int) (int, int) {
+	top, base := s.top()
+	for _, sc := range s.scenes[s.lowest(base, StackedScene[T].DrawCovered):base] {
+		sc.Layout(w, h)
+	}
+	return top.Layout(w, h)
+}
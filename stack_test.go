@@ -0,0 +1,211 @@
+package stagehand
+
+import (
+	"testing"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+type MockStackedScene struct {
+	MockScene
+	drawCovered   bool
+	updateCovered bool
+}
+
+func (m *MockStackedScene) DrawCovered() bool   { return m.drawCovered }
+func (m *MockStackedScene) UpdateCovered() bool { return m.updateCovered }
+
+func TestSceneStack_NewSceneStack(t *testing.T) {
+	mockScene := &MockScene{}
+	ss := NewSceneStack[int](mockScene, 42)
+
+	assert.True(t, mockScene.loadCalled)
+	assert.Equal(t, mockScene, ss.Top())
+	assert.Equal(t, 1, ss.Len())
+}
+
+func TestSceneStack_PushPop(t *testing.T) {
+	bottom := &MockTransitionAwareScene{}
+	overlay := &MockScene{}
+	ss := NewSceneStack[int](bottom, 42)
+
+	ss.Push(overlay)
+	assert.Equal(t, overlay, ss.Top())
+	assert.Equal(t, 2, ss.Len())
+	assert.True(t, bottom.preTransitionCalled)
+	assert.False(t, bottom.unloadCalled)
+	assert.True(t, overlay.loadCalled)
+
+	ss.Pop()
+	assert.Equal(t, bottom, ss.Top())
+	assert.Equal(t, 1, ss.Len())
+	assert.True(t, overlay.unloadCalled)
+	assert.True(t, bottom.postTransitionCalled)
+
+	// The last scene is never popped
+	ss.Pop()
+	assert.Equal(t, bottom, ss.Top())
+	assert.Equal(t, 1, ss.Len())
+}
+
+func TestSceneStack_PushUnawareScene(t *testing.T) {
+	bottom := &MockScene{}
+	overlay := &MockScene{}
+	ss := NewSceneStack[int](bottom, 42)
+
+	ss.Push(overlay)
+	assert.True(t, bottom.unloadCalled)
+	assert.Equal(t, 42, overlay.unloadReturns)
+
+	bottom.loadCalled = false
+	overlay.unloadReturns = 7
+	ss.Pop()
+	assert.True(t, bottom.loadCalled)
+	assert.Equal(t, 7, bottom.unloadReturns)
+}
+
+func TestSceneStack_Replace(t *testing.T) {
+	bottom := &MockScene{}
+	top := &MockScene{}
+	other := &MockScene{}
+	ss := NewSceneStack[int](bottom, 0)
+	ss.Push(top)
+
+	ss.Replace(other)
+	assert.Equal(t, other, ss.Top())
+	assert.Equal(t, 2, ss.Len())
+	assert.True(t, top.unloadCalled)
+	assert.True(t, other.loadCalled)
+}
+
+func TestSceneStack_PopTo(t *testing.T) {
+	bottom := &MockScene{}
+	middle := &MockScene{}
+	aware := &MockTransitionAwareScene{}
+	top := &MockScene{}
+	ss := NewSceneStack[int](bottom, 0)
+	ss.Push(middle)
+	ss.Push(aware)
+	ss.Push(top)
+
+	// Scenes outside the stack are ignored
+	ss.PopTo(&MockScene{})
+	assert.Equal(t, 4, ss.Len())
+
+	// The middle scene was unloaded when it was covered, unlike the TransitionAwareScene
+	assert.True(t, middle.unloadCalled)
+	assert.False(t, aware.unloadCalled)
+	middle.unloadCalled = false
+
+	bottom.loadCalled = false
+	ss.PopTo(bottom)
+	assert.Equal(t, bottom, ss.Top())
+	assert.Equal(t, 1, ss.Len())
+	assert.False(t, middle.unloadCalled)
+	assert.True(t, aware.unloadCalled)
+	assert.True(t, top.unloadCalled)
+	assert.True(t, bottom.loadCalled)
+}
+
+func TestSceneStack_PushWithTransition(t *testing.T) {
+	bottom := &MockTransitionAwareScene{}
+	overlay := &MockScene{}
+	trans := &baseTransitionImplementation{}
+	ss := NewSceneStack[int](bottom, 0)
+
+	ss.PushWithTransition(overlay, trans)
+	assert.Equal(t, overlay, ss.Top())
+	assert.Equal(t, trans, ss.transition)
+	assert.True(t, overlay.loadCalled)
+
+	trans.End()
+	assert.Nil(t, ss.transition)
+	assert.Equal(t, 2, ss.Len())
+	assert.False(t, bottom.unloadCalled)
+	assert.False(t, bottom.postTransitionCalled)
+}
+
+func TestSceneStack_PopWithTransition(t *testing.T) {
+	bottom := &MockTransitionAwareScene{}
+	overlay := &MockScene{}
+	trans := &baseTransitionImplementation{}
+	ss := NewSceneStack[int](bottom, 0)
+	ss.Push(overlay)
+
+	ss.PopWithTransition(trans)
+	assert.Equal(t, bottom, ss.Top())
+	assert.Equal(t, trans, ss.transition)
+	assert.False(t, overlay.unloadCalled)
+
+	trans.End()
+	assert.Nil(t, ss.transition)
+	assert.True(t, overlay.unloadCalled)
+	assert.True(t, bottom.postTransitionCalled)
+}
+
+func TestSceneStack_TransitionCanceling(t *testing.T) {
+	bottom := &MockScene{}
+	overlay := &MockScene{}
+	transA := &baseTransitionImplementation{}
+	transB := &baseTransitionImplementation{}
+	ss := NewSceneStack[int](bottom, 0)
+
+	ss.PushWithTransition(overlay, transA)
+	ss.PopWithTransition(transB)
+	assert.Equal(t, transB, ss.transition)
+	assert.Equal(t, bottom, ss.Top())
+
+	ss.Push(overlay)
+	assert.Nil(t, ss.transition)
+	assert.Equal(t, overlay, ss.Top())
+}
+
+func TestSceneStack_CoveredScenes(t *testing.T) {
+	hidden := &MockStackedScene{drawCovered: true, updateCovered: true}
+	opaque := &MockScene{}
+	frozen := &MockStackedScene{drawCovered: true}
+	top := &MockScene{}
+	ss := NewSceneStack[int](hidden, 0)
+	ss.Push(opaque)
+	ss.Push(frozen)
+	ss.Push(top)
+
+	err := ss.Update()
+	assert.NoError(t, err)
+	assert.True(t, top.updateCalled)
+	assert.False(t, frozen.updateCalled)
+	assert.False(t, opaque.updateCalled)
+	assert.False(t, hidden.updateCalled)
+
+	ss.Draw(ebiten.NewImage(100, 100))
+	assert.True(t, top.drawCalled)
+	assert.True(t, frozen.drawCalled)
+	assert.False(t, opaque.drawCalled)
+	assert.False(t, hidden.drawCalled)
+
+	w, h := ss.Layout(100, 100)
+	assert.Equal(t, 100, w)
+	assert.Equal(t, 100, h)
+	assert.True(t, frozen.layoutCalled)
+	assert.False(t, opaque.layoutCalled)
+}
+
+func TestSceneStack_CoveredScenesDuringTransition(t *testing.T) {
+	live := &MockStackedScene{drawCovered: true, updateCovered: true}
+	from := &MockScene{}
+	to := &MockScene{}
+	trans := &baseTransitionImplementation{}
+	ss := NewSceneStack[int](live, 0)
+	ss.Push(from)
+	ss.PushWithTransition(to, trans)
+
+	err := ss.Update()
+	assert.NoError(t, err)
+	assert.True(t, live.updateCalled)
+	assert.True(t, from.updateCalled)
+	assert.True(t, to.updateCalled)
+
+	ss.Draw(ebiten.NewImage(100, 100))
+	assert.True(t, live.drawCalled)
+}
@@ -0,0 +1,169 @@
+package stagehand
+
+import (
+	"math"
+	"time"
+
+	ebiten "github.com/hajimehoshi/ebiten/v2"
+)
+
+// A ProgressDriver computes how a Timeline advances
+type ProgressDriver interface {
+	Start()                               // Runs when the timeline starts or resumes
+	Next(progress, scale float64) float64 // Returns the progress after one update
+}
+
+// A Timeline drives the normalized progress, from 0 to 1, of a transition
+type Timeline struct {
+	driver   ProgressDriver
+	progress float64
+	scale    float64
+	paused   bool
+	reversed bool // set with SetReversed
+	flipped  bool // set with Reverse, until the timeline starts again
+}
+
+func NewTimeline(driver ProgressDriver) *Timeline {
+	return &Timeline{driver: driver, scale: 1}
+}
+
+// NewFactorTimeline returns a timeline that advances the given factor every update
+func NewFactorTimeline(factor float64) *Timeline {
+	return NewTimeline(&factorDriver{factor: factor})
+}
+
+// NewTicksTimeline returns a timeline that completes after the given number of updates
+func NewTicksTimeline(ticks int) *Timeline {
+	return NewTimeline(&factorDriver{factor: 1 / float64(MaxInt(ticks, 1))})
+}
+
+// NewTicksDurationTimeline returns a timeline that completes after the number of updates that fit in
+// the duration at the current TPS
+func NewTicksDurationTimeline(duration time.Duration) *Timeline {
+	return NewFactorTimeline(DurationToFactor(float64(ebiten.TPS()), duration))
+}
+
+// NewDurationTimeline returns a timeline that completes after the given real-time duration
+func NewDurationTimeline(duration time.Duration) *Timeline {
+	return NewTimeline(&durationDriver{duration: duration})
+}
+
+// NewExternalTimeline returns a timeline that reports the progress returned by the function, e.g. the
+// progress of a loading task
+func NewExternalTimeline(progress func() float64) *Timeline {
+	return NewTimeline(externalDriver(progress))
+}
+
+// Start resets the progress and the direction flipped by Reverse and starts the timeline
+func (tl *Timeline) Start() {
+	tl.progress = 0
+	tl.paused = false
+	tl.flipped = false
+	tl.driver.Start()
+}
+
+// Update advances the timeline, it does nothing while paused
+func (tl *Timeline) Update() {
+	if tl.paused {
+		return
+	}
+	tl.progress = math.Max(0, math.Min(1, tl.driver.Next(tl.progress, tl.scale)))
+}
+
+// Progress returns the normalized progress of the timeline, it goes from 1 to 0 when reversed
+func (tl *Timeline) Progress() float64 {
+	if tl.reversed != tl.flipped {
+		return 1 - tl.progress
+	}
+	return tl.progress
+}
+
+// Done reports whether the timeline is complete
+func (tl *Timeline) Done() bool {
+	return tl.progress >= 1
+}
+
+func (tl *Timeline) Pause() {
+	tl.paused = true
+}
+
+func (tl *Timeline) Resume() {
+	if tl.paused {
+		tl.paused = false
+		tl.driver.Start()
+	}
+}
+
+func (tl *Timeline) Paused() bool {
+	return tl.paused
+}
+
+// SetReversed makes the timeline report its progress backwards
+func (tl *Timeline) SetReversed(reversed bool) {
+	tl.reversed = reversed
+}
+
+func (tl *Timeline) Reversed() bool {
+	return tl.reversed
+}
+
+// Reverse flips the direction of the timeline keeping its current progress, until it starts again.
+// External timelines follow their progress and can't be flipped
+func (tl *Timeline) Reverse() {
+	if !tl.Reversible() {
+		return
+	}
+	tl.progress = 1 - tl.progress
+	tl.flipped = !tl.flipped
+}
+
+// Reversible reports whether Reverse can play the timeline back
+func (tl *Timeline) Reversible() bool {
+	_, external := tl.driver.(externalDriver)
+	return !external
+}
+
+// SetTimeScale changes the speed of the timeline, 1 is the normal speed
+func (tl *Timeline) SetTimeScale(scale float64) {
+	tl.scale = scale
+}
+
+func (tl *Timeline) TimeScale() float64 {
+	return tl.scale
+}
+
+type factorDriver struct {
+	factor float64
+}
+
+func (d *factorDriver) Start() {}
+
+func (d *factorDriver) Next(progress, scale float64) float64 {
+	return progress + d.factor*scale
+}
+
+type durationDriver struct {
+	duration time.Duration
+	last     time.Time
+}
+
+func (d *durationDriver) Start() {
+	d.last = Clock.Now()
+}
+
+func (d *durationDriver) Next(progress, scale float64) float64 {
+	if d.duration <= 0 {
+		return 1
+	}
+	elapsed := CalculateProgress(d.last, d.duration)
+	d.last = Clock.Now()
+	return progress + elapsed*scale
+}
+
+type externalDriver func() float64
+
+func (d externalDriver) Start() {}
+
+func (d externalDriver) Next(progress, scale float64) float64 {
+	return d()
+}
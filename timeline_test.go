@@ -0,0 +1,162 @@
+package stagehand
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFactorTimeline(t *testing.T) {
+	tl := NewFactorTimeline(.25)
+	tl.Start()
+	assert.Equal(t, 0., tl.Progress())
+
+	tl.Update()
+	assert.Equal(t, .25, tl.Progress())
+	assert.False(t, tl.Done())
+
+	tl.SetTimeScale(2)
+	tl.Update()
+	assert.Equal(t, .75, tl.Progress())
+
+	// Progress is clamped
+	tl.Update()
+	assert.Equal(t, 1., tl.Progress())
+	assert.True(t, tl.Done())
+
+	// Restarting resets the progress
+	tl.Start()
+	assert.Equal(t, 0., tl.Progress())
+}
+
+func TestTicksTimeline(t *testing.T) {
+	tl := NewTicksTimeline(4)
+	tl.Start()
+	for i := 0; i < 3; i++ {
+		tl.Update()
+		assert.False(t, tl.Done())
+	}
+	tl.Update()
+	assert.True(t, tl.Done())
+}
+
+func TestDurationTimeline(t *testing.T) {
+	Clock = &MockClock{currentTime: time.Now()}
+	tl := NewDurationTimeline(time.Second)
+	tl.Start()
+
+	tl.Update()
+	assert.Equal(t, 0., tl.Progress())
+
+	Clock.Sleep(time.Second / 4)
+	tl.Update()
+	assert.Equal(t, .25, tl.Progress())
+
+	// Time doesn't count while paused
+	tl.Pause()
+	assert.True(t, tl.Paused())
+	Clock.Sleep(time.Second / 4)
+	tl.Update()
+	assert.Equal(t, .25, tl.Progress())
+
+	tl.Resume()
+	assert.False(t, tl.Paused())
+	Clock.Sleep(time.Second / 4)
+	tl.Update()
+	assert.Equal(t, .5, tl.Progress())
+
+	tl.SetTimeScale(.5)
+	assert.Equal(t, .5, tl.TimeScale())
+	Clock.Sleep(time.Second / 2)
+	tl.Update()
+	assert.Equal(t, .75, tl.Progress())
+
+	Clock.Sleep(time.Second)
+	tl.Update()
+	assert.True(t, tl.Done())
+}
+
+func TestDurationTimeline_Zero(t *testing.T) {
+	tl := NewDurationTimeline(0)
+	tl.Start()
+	tl.Update()
+	assert.True(t, tl.Done())
+}
+
+func TestExternalTimeline(t *testing.T) {
+	progress := 0.
+	tl := NewExternalTimeline(func() float64 { return progress })
+	tl.Start()
+
+	progress = .3
+	tl.Update()
+	assert.Equal(t, .3, tl.Progress())
+
+	progress = 2
+	tl.Update()
+	assert.True(t, tl.Done())
+}
+
+func TestTimeline_Reversed(t *testing.T) {
+	tl := NewTicksTimeline(4)
+	tl.SetReversed(true)
+	tl.Start()
+	assert.True(t, tl.Reversed())
+	assert.Equal(t, 1., tl.Progress())
+
+	tl.Update()
+	assert.Equal(t, .75, tl.Progress())
+	assert.False(t, tl.Done())
+
+	for i := 0; i < 3; i++ {
+		tl.Update()
+	}
+	assert.Equal(t, 0., tl.Progress())
+	assert.True(t, tl.Done())
+}
+
+func TestTimeline_Reverse(t *testing.T) {
+	tl := NewTicksTimeline(4)
+	tl.Start()
+	tl.Update()
+	assert.Equal(t, .25, tl.Progress())
+
+	// The progress goes back from where it was
+	tl.Reverse()
+	assert.Equal(t, .25, tl.Progress())
+	tl.Update()
+	assert.Equal(t, 0., tl.Progress())
+	assert.True(t, tl.Done())
+
+	// Starting again plays forwards
+	tl.Start()
+	tl.Update()
+	assert.Equal(t, .25, tl.Progress())
+
+	// External timelines can't be flipped
+	external := NewExternalTimeline(func() float64 { return .25 })
+	external.Update()
+	external.Reverse()
+	assert.False(t, external.Reversible())
+	assert.Equal(t, .25, external.Progress())
+}
+
+func TestBaseTransition_Timeline(t *testing.T) {
+	from := &MockScene{}
+	to := &MockScene{}
+	trans := &baseTransitionImplementation{}
+	trans.SetTimeline(NewTicksTimeline(2))
+	sm := NewSceneManager[int](from, 0)
+	sm.SwitchWithTransition(to, trans)
+
+	err := sm.Update()
+	assert.NoError(t, err)
+	assert.Equal(t, .5, trans.Progress())
+	assert.Equal(t, trans, sm.current)
+
+	err = sm.Update()
+	assert.NoError(t, err)
+	assert.Equal(t, 1., trans.Progress())
+	assert.Equal(t, to, sm.current)
+}
@@ -1,6 +1,9 @@
 package stagehand
 
 import (
+	"image"
+	"image/color"
+	"math"
 	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
@@ -12,22 +15,93 @@ type SceneTransition[T any] interface {
 	End()
 }
 
+// A TransitionOption configures the optional behavior of a transition
+type TransitionOption func(*transitionOptions)
+
+type transitionOptions struct {
+	easing Easing
+}
+
+// WithEasing sets the curve applied to the progress of the transition when it's drawn
+func WithEasing(easing Easing) TransitionOption {
+	return func(o *transitionOptions) {
+		o.easing = easing
+	}
+}
+
 // A helper class that implements basic transition functionality
 type BaseTransition[T any] struct {
 	fromScene Scene[T]
 	toScene   Scene[T]
 	sm        SceneController[T]
+	options   transitionOptions
+	timeline  *Timeline
+	fromImg   RenderTarget
+	toImg     RenderTarget
+	drawOp    ebiten.DrawImageOptions // reused by DrawOptions
+}
+
+// SetOptions applies the given options to the transition
+func (t *BaseTransition[T]) SetOptions(opts ...TransitionOption) {
+	for _, opt := range opts {
+		opt(&t.options)
+	}
+}
+
+// SetTimeline sets the timeline that drives the progress of the transition
+func (t *BaseTransition[T]) SetTimeline(timeline *Timeline) {
+	t.timeline = timeline
+}
+
+// Timeline returns the timeline of the transition, it can be used to pause or scale it
+func (t *BaseTransition[T]) Timeline() *Timeline {
+	return t.timeline
+}
+
+// Progress returns the linear progress of the transition
+func (t *BaseTransition[T]) Progress() float64 {
+	if t.timeline == nil {
+		return 0
+	}
+	return t.timeline.Progress()
+}
+
+// Ease applies the easing curve of the transition to a linear progress
+func (t *BaseTransition[T]) Ease(progress float64) float64 {
+	if t.options.easing == nil {
+		return progress
+	}
+	return t.options.easing(progress)
 }
 
 func (t *BaseTransition[T]) Start(fromScene, toScene Scene[T], sm SceneController[T]) {
 	t.fromScene = fromScene
 	t.toScene = toScene
 	t.sm = sm
+	if t.timeline != nil {
+		t.timeline.Start()
+	}
 }
 
-// Updates the transition state
+// Advance updates the timeline and reports whether it's complete
+func (t *BaseTransition[T]) Advance() bool {
+	if t.timeline == nil {
+		return false
+	}
+	t.timeline.Update()
+	return t.timeline.Done()
+}
+
+// Updates the transition state, ending it once the timeline is complete
 func (t *BaseTransition[T]) Update() error {
-	// Update the scenes
+	if t.Advance() {
+		t.End()
+	}
+	return t.UpdateScenes()
+}
+
+// UpdateScenes updates both scenes of the transition
+func (t *BaseTransition[T]) UpdateScenes() error {
 	err := t.fromScene.Update()
 	if err != nil {
 		return err
@@ -49,28 +123,110 @@ func (t *BaseTransition[T]) Layout(outsideWidth, outsideHeight int) (int, int) {
 	return MaxInt(sw, tw), MaxInt(sh, th)
 }
 
+// PreDraw returns the rendered frames for the scenes, the images are reused across frames and released
+// when the transition ends
+func (t *BaseTransition[T]) PreDraw(bounds image.Rectangle) (*ebiten.Image, *ebiten.Image) {
+	fromImg := t.fromImg.Image(bounds.Dx(), bounds.Dy())
+	t.fromScene.Draw(fromImg)
+
+	toImg := t.toImg.Image(bounds.Dx(), bounds.Dy())
+	t.toScene.Draw(toImg)
+
+	return toImg, fromImg
+}
+
+// DrawOptions returns cleared options to draw an image, they are reused across draws so they are only
+// valid until the next call
+func (t *BaseTransition[T]) DrawOptions() *ebiten.DrawImageOptions {
+	t.drawOp = ebiten.DrawImageOptions{}
+	return &t.drawOp
+}
+
 // Ends transition to the next scene
 func (t *BaseTransition[T]) End() {
+	t.fromImg.Dispose()
+	t.toImg.Dispose()
 	t.sm.ReturnFromTransition(t.toScene, t.fromScene)
 }
 
 type FadeTransition[T any] struct {
 	BaseTransition[T]
-	factor       float32 // factor used for the fade-in/fade-out effect
 	alpha        float32 // alpha value used for the fade-in/fade-out effect
 	isFadingIn   bool    // whether the transition is currently fading in or out
 	frameUpdated bool
+	mode         FadeMode
+	color        color.Color // color faded through by FadeThroughColor
+	out, hold    float64     // fractions of the timeline spent fading out and holding the color
+}
+
+// A FadeMode selects how the scenes are faded
+type FadeMode int
+
+const (
+	// FadeThroughColor fades the origin scene out to a color and then fades the destination in
+	FadeThroughColor FadeMode = iota
+	// Crossfade blends the origin scene into the destination
+	Crossfade
+)
+
+// NewFadeTransition returns a fade where the alpha changes by the factor every frame
+func NewFadeTransition[T any](factor float32, opts ...TransitionOption) *FadeTransition[T] {
+	// The alpha goes up and down, so the whole transition advances half the factor
+	return NewTimelineFadeTransition[T](NewFactorTimeline(float64(factor)/2), opts...)
 }
 
-func NewFadeTransition[T any](factor float32) *FadeTransition[T] {
-	return &FadeTransition[T]{
-		factor: factor,
+// NewTimelineFadeTransition returns a fade through black driven by the given timeline, half of the
+// timeline is spent on each fade
+func NewTimelineFadeTransition[T any](timeline *Timeline, opts ...TransitionOption) *FadeTransition[T] {
+	t := &FadeTransition[T]{
+		mode:  FadeThroughColor,
+		color: color.Black,
+		out:   .5,
 	}
+	t.SetTimeline(timeline)
+	t.SetOptions(opts...)
+	return t
+}
+
+// NewFadeColorTransition returns a fade through the color with separate durations to fade out, hold
+// the color and fade in
+func NewFadeColorTransition[T any](c color.Color, out, hold, in time.Duration, opts ...TransitionOption) *FadeTransition[T] {
+	t := NewTimelineFadeTransition[T](NewDurationTimeline(out+hold+in), opts...)
+	return t.SetColor(c).SetPhases(out.Seconds(), hold.Seconds(), in.Seconds())
+}
+
+// NewCrossfadeTransition returns a fade that blends both scenes simultaneously
+func NewCrossfadeTransition[T any](timeline *Timeline, opts ...TransitionOption) *FadeTransition[T] {
+	t := NewTimelineFadeTransition[T](timeline, opts...)
+	t.mode = Crossfade
+	return t
+}
+
+// SetColor sets the color faded through, it has no effect on a crossfade
+func (t *FadeTransition[T]) SetColor(c color.Color) *FadeTransition[T] {
+	t.color = c
+	return t
+}
+
+// SetPhases splits the timeline between fading out, holding the color and fading in, the values are
+// relative weights. It has no effect on a crossfade
+func (t *FadeTransition[T]) SetPhases(out, hold, in float64) *FadeTransition[T] {
+	out, hold, in = math.Max(0, out), math.Max(0, hold), math.Max(0, in)
+	if total := out + hold + in; total > 0 {
+		t.out, t.hold = out/total, hold/total
+	}
+	return t
+}
+
+// Mode returns how the scenes are faded
+func (t *FadeTransition[T]) Mode() FadeMode {
+	return t.mode
 }
 
 // Start starts the transition from the given "from" scene to the given "to" scene
 func (t *FadeTransition[T]) Start(fromScene, toScene Scene[T], sm SceneController[T]) {
 	t.BaseTransition.Start(fromScene, toScene, sm)
+	t.frameUpdated = false
 	t.alpha = 0
 	t.isFadingIn = true
 }
@@ -79,52 +235,73 @@ func (t *FadeTransition[T]) Start(fromScene, toScene Scene[T], sm SceneControlle
 func (t *FadeTransition[T]) Update() error {
 	if !t.frameUpdated {
 		// Update the alpha value based on the current state of the transition
-		if t.isFadingIn {
-			t.alpha += t.factor
-			if t.alpha >= 1.0 {
-				t.alpha = 1.0
-				t.isFadingIn = false
-			}
-		} else {
-			t.alpha -= t.factor
-			if t.alpha <= 0.0 {
-				t.alpha = 0.0
-				t.End()
-			}
+		done := t.Advance()
+		t.alpha, t.isFadingIn = t.phase(t.Progress())
+		if done {
+			t.End()
 		}
 		t.frameUpdated = true
 	}
 
 	// Update the scenes
-	return t.BaseTransition.Update()
+	return t.UpdateScenes()
+}
+
+// phase returns the alpha and whether the color is fading in at the given progress. For a crossfade
+// the alpha is the opacity of the destination
+func (t *FadeTransition[T]) phase(progress float64) (float32, bool) {
+	if t.mode == Crossfade {
+		return float32(progress), true
+	}
+	in := 1 - t.out - t.hold
+	switch {
+	case progress < t.out:
+		return float32(progress / t.out), true
+	case progress < t.out+t.hold:
+		return 1, false
+	case in <= 0:
+		return 0, false
+	}
+	return float32(1 - (progress-t.out-t.hold)/in), false
 }
 
 // Draw draws the transition effect
 func (t *FadeTransition[T]) Draw(screen *ebiten.Image) {
-	toImg, fromImg := PreDraw(screen.Bounds(), t.fromScene, t.toScene)
-	toOp, fromOp := &ebiten.DrawImageOptions{}, &ebiten.DrawImageOptions{}
-
-	// Draw the scenes with the appropriate alpha value
-	if t.isFadingIn {
-		toOp.ColorScale.ScaleAlpha(t.alpha)
-		screen.DrawImage(fromImg, toOp)
-
-		fromOp.ColorScale.ScaleAlpha(1.0 - t.alpha)
-		screen.DrawImage(fromImg, fromOp)
-	} else {
-		fromOp.ColorScale.ScaleAlpha(t.alpha)
-		screen.DrawImage(fromImg, fromOp)
-
-		toOp.ColorScale.ScaleAlpha(1.0 - t.alpha)
+	toImg, fromImg := t.PreDraw(screen.Bounds())
+
+	switch {
+	case t.mode == Crossfade:
+		// Drawing the destination over the opaque origin blends them linearly
+		screen.DrawImage(fromImg, t.DrawOptions())
+		toOp := t.DrawOptions()
+		toOp.ColorScale.ScaleAlpha(clampAlpha(t.Ease(float64(t.alpha))))
 		screen.DrawImage(toImg, toOp)
+	case t.isFadingIn:
+		screen.DrawImage(fromImg, t.DrawOptions())
+		t.drawColor(screen, clampAlpha(t.Ease(float64(t.alpha))))
+	default:
+		screen.DrawImage(toImg, t.DrawOptions())
+		t.drawColor(screen, clampAlpha(1-t.Ease(float64(1-t.alpha))))
 	}
 	t.frameUpdated = false
 }
 
+// drawColor covers the screen with the fade color at the given alpha
+func (t *FadeTransition[T]) drawColor(screen *ebiten.Image, alpha float32) {
+	op := t.DrawOptions()
+	op.GeoM.Scale(float64(screen.Bounds().Dx()), float64(screen.Bounds().Dy()))
+	op.ColorScale.ScaleWithColor(t.color)
+	op.ColorScale.ScaleAlpha(alpha)
+	screen.DrawImage(whiteSubImage, op)
+}
+
 type SlideTransition[T any] struct {
 	BaseTransition[T]
-	factor       float64 // factor used for the slide-in/slide-out effect
 	direction    SlideDirection
+	dx, dy       float64 // unit vector of the movement
+	mode         SlideMode
+	parallax     float64 // relative speed of the background scene
+	hasParallax  bool
 	offset       float64
 	frameUpdated bool
 }
@@ -138,16 +315,99 @@ const (
 	BottomToTop
 )
 
-func NewSlideTransition[T any](direction SlideDirection, factor float64) *SlideTransition[T] {
-	return &SlideTransition[T]{
+// vector returns the unit vector of the movement for the direction
+func (d SlideDirection) vector() (float64, float64) {
+	switch d {
+	case LeftToRight:
+		return 1, 0
+	case RightToLeft:
+		return -1, 0
+	case TopToBottom:
+		return 0, 1
+	case BottomToTop:
+		return 0, -1
+	}
+	return 0, 0
+}
+
+// A SlideMode selects which scenes move during a slide
+type SlideMode int
+
+const (
+	// SlidePush moves both scenes, the new scene pushes the old one out of the screen
+	SlidePush SlideMode = iota
+	// SlideCover moves the new scene over the stationary old scene
+	SlideCover
+	// SlideReveal moves the old scene away uncovering the stationary new scene
+	SlideReveal
+)
+
+// NewSlideTransition returns a slide where the offset changes by the factor every frame
+func NewSlideTransition[T any](direction SlideDirection, factor float64, opts ...TransitionOption) *SlideTransition[T] {
+	return NewTimelineSlideTransition[T](direction, NewFactorTimeline(factor), opts...)
+}
+
+// NewTimelineSlideTransition returns a slide driven by the given timeline
+func NewTimelineSlideTransition[T any](direction SlideDirection, timeline *Timeline, opts ...TransitionOption) *SlideTransition[T] {
+	t := &SlideTransition[T]{
 		direction: direction,
-		factor:    factor,
 	}
+	t.dx, t.dy = direction.vector()
+	t.SetTimeline(timeline)
+	t.SetOptions(opts...)
+	return t
+}
+
+// NewAngleSlideTransition returns a slide towards the angle, in radians, driven by the given timeline.
+// An angle of 0 moves from left to right and Pi/2 from top to bottom
+func NewAngleSlideTransition[T any](angle float64, timeline *Timeline, opts ...TransitionOption) *SlideTransition[T] {
+	return NewTimelineSlideTransition[T](LeftToRight, timeline, opts...).SetAngle(angle)
+}
+
+// SetAngle sets the direction of the movement in radians, it replaces the direction of the transition
+func (t *SlideTransition[T]) SetAngle(angle float64) *SlideTransition[T] {
+	t.dx, t.dy = math.Cos(angle), math.Sin(angle)
+	return t
+}
+
+// SetMode sets which scenes move during the slide
+func (t *SlideTransition[T]) SetMode(mode SlideMode) *SlideTransition[T] {
+	t.mode = mode
+	return t
+}
+
+// SetParallax sets the speed of the background scene relative to the foreground scene. The background
+// is the old scene when pushing or covering and the new scene when revealing. By default the
+// background is stationary, except when pushing
+func (t *SlideTransition[T]) SetParallax(speed float64) *SlideTransition[T] {
+	t.parallax = speed
+	t.hasParallax = true
+	return t
+}
+
+// Mode returns which scenes move during the slide
+func (t *SlideTransition[T]) Mode() SlideMode {
+	return t.mode
+}
+
+// speeds returns how far the old and the new scenes move relative to a full slide
+func (t *SlideTransition[T]) speeds() (from, to float64) {
+	background := 0.
+	if t.hasParallax {
+		background = t.parallax
+	} else if t.mode == SlidePush {
+		background = 1
+	}
+	if t.mode == SlideReveal {
+		return 1, background
+	}
+	return background, 1
 }
 
 // Start starts the transition from the given "from" scene to the given "to" scene
 func (t *SlideTransition[T]) Start(fromScene Scene[T], toScene Scene[T], sm SceneController[T]) {
 	t.BaseTransition.Start(fromScene, toScene, sm)
+	t.frameUpdated = false
 	t.offset = 0
 }
 
@@ -155,134 +415,83 @@ func (t *SlideTransition[T]) Start(fromScene Scene[T], toScene Scene[T], sm Scen
 func (t *SlideTransition[T]) Update() error {
 	if !t.frameUpdated {
 		// Update the offset value based on the current state of the transition
-		if t.offset >= 1.0 {
-			t.offset = 1.0
+		done := t.Advance()
+		t.offset = t.Progress()
+		if done {
 			t.End()
-		} else {
-			t.offset += t.factor
 		}
 		t.frameUpdated = true
 	}
 
 	// Update the scenes
-	return t.BaseTransition.Update()
+	return t.UpdateScenes()
 }
 
 // Draw draws the transition effect
 func (t *SlideTransition[T]) Draw(screen *ebiten.Image) {
-	toImg, fromImg := PreDraw(screen.Bounds(), t.fromScene, t.toScene)
-	toOp, fromOp := &ebiten.DrawImageOptions{}, &ebiten.DrawImageOptions{}
+	toImg, fromImg := t.PreDraw(screen.Bounds())
 
 	w, h := float64(screen.Bounds().Dx()), float64(screen.Bounds().Dy())
+	offset := t.Ease(t.offset)
 
-	var x, y float64
+	// The distance along the direction that moves a scene completely out of the screen
+	distance := math.Abs(w*t.dx) + math.Abs(h*t.dy)
+	x, y := t.dx*distance, t.dy*distance
+	fromSpeed, toSpeed := t.speeds()
 
-	switch t.direction {
-	case LeftToRight:
-		x = w * t.offset
-		fromOp.GeoM.Translate(x, 0)
-		toOp.GeoM.Translate(x-w, 0)
-	case RightToLeft:
-		x = w * (1 - t.offset)
-		fromOp.GeoM.Translate(x-w, 0)
-		toOp.GeoM.Translate(x, 0)
-	case TopToBottom:
-		y = h * t.offset
-		fromOp.GeoM.Translate(0, y)
-		toOp.GeoM.Translate(0, y-h)
-	case BottomToTop:
-		y = h * (1 - t.offset)
-		fromOp.GeoM.Translate(0, y-h)
-		toOp.GeoM.Translate(0, y)
+	fromX, fromY := x*offset*fromSpeed, y*offset*fromSpeed
+	toX, toY := x*(offset-1)*toSpeed, y*(offset-1)*toSpeed
+
+	// The foreground scene is drawn last
+	if t.mode == SlideReveal {
+		t.drawAt(screen, toImg, toX, toY)
+		t.drawAt(screen, fromImg, fromX, fromY)
+	} else {
+		t.drawAt(screen, fromImg, fromX, fromY)
+		t.drawAt(screen, toImg, toX, toY)
 	}
-	screen.DrawImage(toImg, toOp)
-	screen.DrawImage(fromImg, fromOp)
 	t.frameUpdated = false
 }
 
-// Timed Variants of the transition
+// drawAt draws the image translated by the offset
+func (t *SlideTransition[T]) drawAt(screen, img *ebiten.Image, x, y float64) {
+	op := t.DrawOptions()
+	op.GeoM.Translate(x, y)
+	screen.DrawImage(img, op)
+}
+
+// Timed Variants of the transition, kept for compatibility
 
-func NewTicksTimedFadeTransition[T any](duration time.Duration) *FadeTransition[T] {
-	return NewFadeTransition[T](float32(DurationToFactor(float64(ebiten.TPS()), duration)))
+// Deprecated: Use NewTimelineFadeTransition with NewTicksDurationTimeline instead.
+func NewTicksTimedFadeTransition[T any](duration time.Duration, opts ...TransitionOption) *FadeTransition[T] {
+	return NewTimelineFadeTransition[T](NewTicksDurationTimeline(duration), opts...)
 }
 
+// Deprecated: Use FadeTransition with a duration Timeline instead.
 type TimedFadeTransition[T any] struct {
 	FadeTransition[T]
-	initialTime time.Time
-	duration    time.Duration
 }
 
-func NewDurationTimedFadeTransition[T any](duration time.Duration) *TimedFadeTransition[T] {
+// Deprecated: Use NewTimelineFadeTransition with NewDurationTimeline instead.
+func NewDurationTimedFadeTransition[T any](duration time.Duration, opts ...TransitionOption) *TimedFadeTransition[T] {
 	return &TimedFadeTransition[T]{
-		duration:       duration,
-		FadeTransition: *NewFadeTransition[T](0.),
+		FadeTransition: *NewTimelineFadeTransition[T](NewDurationTimeline(duration), opts...),
 	}
 }
 
-func (t *TimedFadeTransition[T]) Start(fromScene, toScene Scene[T], sm SceneController[T]) {
-	t.FadeTransition.Start(fromScene, toScene, sm)
-	t.initialTime = Clock.Now()
-}
-
-func (t *TimedFadeTransition[T]) Update() error {
-	if !t.frameUpdated {
-		// Update the alpha value based on the current state of the transition
-		if t.isFadingIn {
-			t.alpha = float32(CalculateProgress(t.initialTime, t.duration/2))
-			if t.alpha >= 1.0 {
-				t.alpha = 1.0
-				t.isFadingIn = false
-			}
-		} else {
-			t.alpha = 1 - float32(CalculateProgress(t.initialTime.Add(t.duration/2), t.duration/2))
-			if t.alpha <= 0.0 {
-				t.alpha = 0.0
-				t.End()
-			}
-		}
-		t.frameUpdated = true
-	}
-
-	// Update the scenes
-	return t.BaseTransition.Update()
-
-}
-
-func NewTicksTimedSlideTransition[T any](direction SlideDirection, duration time.Duration) *SlideTransition[T] {
-	return NewSlideTransition[T](direction, DurationToFactor(float64(ebiten.TPS()), duration))
+// Deprecated: Use NewTimelineSlideTransition with NewTicksDurationTimeline instead.
+func NewTicksTimedSlideTransition[T any](direction SlideDirection, duration time.Duration, opts ...TransitionOption) *SlideTransition[T] {
+	return NewTimelineSlideTransition[T](direction, NewTicksDurationTimeline(duration), opts...)
 }
 
+// Deprecated: Use SlideTransition with a duration Timeline instead.
 type TimedSlideTransition[T any] struct {
 	SlideTransition[T]
-	initialTime time.Time
-	duration    time.Duration
 }
 
-func NewDurationTimedSlideTransition[T any](direction SlideDirection, duration time.Duration) *TimedSlideTransition[T] {
+// Deprecated: Use NewTimelineSlideTransition with NewDurationTimeline instead.
+func NewDurationTimedSlideTransition[T any](direction SlideDirection, duration time.Duration, opts ...TransitionOption) *TimedSlideTransition[T] {
 	return &TimedSlideTransition[T]{
-		duration:        duration,
-		SlideTransition: *NewSlideTransition[T](direction, 0.),
+		SlideTransition: *NewTimelineSlideTransition[T](direction, NewDurationTimeline(duration), opts...),
 	}
 }
-
-func (t *TimedSlideTransition[T]) Start(fromScene, toScene Scene[T], sm SceneController[T]) {
-	t.SlideTransition.Start(fromScene, toScene, sm)
-	t.initialTime = Clock.Now()
-}
-
-func (t *TimedSlideTransition[T]) Update() error {
-	if !t.frameUpdated {
-		// Update the offset value based on the current state of the transition
-		if t.offset >= 1.0 {
-			t.offset = 1.0
-			t.End()
-		} else {
-			t.offset = CalculateProgress(t.initialTime, t.duration)
-		}
-		t.frameUpdated = true
-	}
-
-	// Update the scenes
-	return t.BaseTransition.Update()
-
-}
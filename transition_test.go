@@ -2,6 +2,9 @@ package stagehand
 
 import (
 	"fmt"
+	"image"
+	"image/color"
+	"math"
 	"testing"
 	"time"
 
@@ -193,7 +196,7 @@ func TestFadeTransition_Start(t *testing.T) {
 
 	assert.Equal(t, from, trans.fromScene)
 	assert.Equal(t, to, trans.toScene)
-	assert.Equal(t, float32(.5), trans.factor)
+	assert.Equal(t, .25, trans.timeline.driver.(*factorDriver).factor) // Half the factor for each fade
 	assert.True(t, trans.isFadingIn)
 }
 
@@ -212,6 +215,97 @@ func TestFadeTransition_Draw(t *testing.T) {
 	assert.False(t, trans.frameUpdated)
 }
 
+func TestFadeTransition_SetColor(t *testing.T) {
+	from := &MockScene{}
+	to := &MockScene{}
+	trans := NewFadeTransition[int](.5)
+	assert.Equal(t, FadeThroughColor, trans.Mode())
+	assert.Equal(t, color.Black, trans.color)
+
+	assert.Equal(t, trans, trans.SetColor(color.White))
+	assert.Equal(t, color.White, trans.color)
+
+	trans.Start(from, to, nil)
+	trans.Update()
+	trans.Draw(ebiten.NewImage(100, 100))
+	assert.False(t, trans.frameUpdated)
+}
+
+func TestFadeTransition_Phases(t *testing.T) {
+	now := time.Now()
+	Clock = &MockClock{currentTime: now}
+	from := &MockScene{}
+	to := &MockScene{}
+	trans := NewFadeColorTransition[int](color.White, time.Second, 2*time.Second, time.Second)
+	sm := NewSceneManager[int](from, 0)
+	sm.SwitchWithTransition(to, trans)
+	assert.Equal(t, .25, trans.out)
+	assert.Equal(t, .5, trans.hold)
+
+	step := func() {
+		Clock.Sleep(time.Second / 2)
+		trans.frameUpdated = false
+		sm.Update()
+	}
+
+	step()
+	assert.Equal(t, float32(.5), trans.alpha)
+	assert.True(t, trans.isFadingIn)
+
+	// The color is held
+	step()
+	assert.Equal(t, float32(1), trans.alpha)
+	assert.False(t, trans.isFadingIn)
+	for i := 0; i < 4; i++ {
+		step()
+	}
+	assert.Equal(t, float32(1), trans.alpha)
+	assert.Equal(t, trans, sm.current)
+
+	step()
+	assert.Equal(t, float32(.5), trans.alpha)
+	step()
+	assert.Equal(t, float32(0), trans.alpha)
+	assert.Equal(t, to, sm.current)
+}
+
+func TestFadeTransition_SetPhases(t *testing.T) {
+	trans := NewFadeTransition[int](.5)
+
+	trans.SetPhases(1, 0, 0)
+	alpha, fadingIn := trans.phase(.5)
+	assert.Equal(t, float32(.5), alpha)
+	assert.True(t, fadingIn)
+	alpha, _ = trans.phase(1)
+	assert.Equal(t, float32(0), alpha)
+
+	// Invalid weights are ignored
+	trans.SetPhases(0, 0, 0)
+	assert.Equal(t, 1., trans.out)
+}
+
+func TestCrossfadeTransition(t *testing.T) {
+	from := &MockScene{}
+	to := &MockScene{}
+	trans := NewCrossfadeTransition[int](NewTicksTimeline(4))
+	sm := NewSceneManager[int](from, 0)
+	sm.SwitchWithTransition(to, trans)
+	assert.Equal(t, Crossfade, trans.Mode())
+
+	for i, expected := range []float32{.25, .5, .75} {
+		trans.frameUpdated = false
+		sm.Update()
+		assert.Equal(t, expected, trans.alpha, i)
+		assert.True(t, trans.isFadingIn)
+	}
+	trans.Draw(ebiten.NewImage(10, 10))
+	assert.False(t, trans.frameUpdated)
+
+	trans.frameUpdated = false
+	sm.Update()
+	assert.Equal(t, to, sm.current)
+}
+
 func TestSlideTransition_UpdateOncePerFrame(t *testing.T) {
 	var value float64 = .6
 	from := &MockScene{}
@@ -268,7 +362,7 @@ func TestSlideTransition_Start(t *testing.T) {
 
 	assert.Equal(t, from, trans.fromScene)
 	assert.Equal(t, to, trans.toScene)
-	assert.Equal(t, .5, trans.factor)
+	assert.Equal(t, .5, trans.timeline.driver.(*factorDriver).factor)
 	assert.Equal(t, TopToBottom, trans.direction)
 }
 
@@ -289,6 +383,54 @@ func TestSlideTransition_Draw(t *testing.T) {
 	}
 }
 
+func TestSlideTransition_Modes(t *testing.T) {
+	tests := []struct {
+		name     string
+		trans    *SlideTransition[int]
+		from, to float64
+	}{
+		{"Push", NewSlideTransition[int](LeftToRight, .5), 1, 1},
+		{"Cover", NewSlideTransition[int](LeftToRight, .5).SetMode(SlideCover), 0, 1},
+		{"Reveal", NewSlideTransition[int](LeftToRight, .5).SetMode(SlideReveal), 1, 0},
+		{"PushParallax", NewSlideTransition[int](LeftToRight, .5).SetParallax(.5), .5, 1},
+		{"CoverParallax", NewSlideTransition[int](LeftToRight, .5).SetMode(SlideCover).SetParallax(.25), .25, 1},
+		{"RevealParallax", NewSlideTransition[int](LeftToRight, .5).SetMode(SlideReveal).SetParallax(.25), 1, .25},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to := tt.trans.speeds()
+			assert.Equal(t, tt.from, from)
+			assert.Equal(t, tt.to, to)
+
+			tt.trans.Start(&MockScene{}, &MockScene{}, nil)
+			tt.trans.Update()
+			tt.trans.Draw(ebiten.NewImage(100, 100))
+			assert.False(t, tt.trans.frameUpdated)
+		})
+	}
+}
+
+func TestSlideTransition_Angle(t *testing.T) {
+	for direction, vector := range map[SlideDirection][2]float64{
+		LeftToRight: {1, 0},
+		RightToLeft: {-1, 0},
+		TopToBottom: {0, 1},
+		BottomToTop: {0, -1},
+	} {
+		trans := NewSlideTransition[int](direction, .5)
+		assert.Equal(t, vector, [2]float64{trans.dx, trans.dy})
+	}
+
+	trans := NewAngleSlideTransition[int](math.Pi/4, NewTicksTimeline(2))
+	assert.InDelta(t, math.Sqrt2/2, trans.dx, 1e-9)
+	assert.InDelta(t, math.Sqrt2/2, trans.dy, 1e-9)
+
+	trans.Start(&MockScene{}, &MockScene{}, nil)
+	trans.Update()
+	trans.Draw(ebiten.NewImage(100, 100))
+	assert.Equal(t, .5, trans.offset)
+}
+
 func TestTimedFadeTransition_Update(t *testing.T) {
 	now := time.Now()
 	Clock = &MockClock{currentTime: now}
@@ -348,7 +490,7 @@ func TestTimedFadeTransition_Start(t *testing.T) {
 
 	assert.Equal(t, from, trans.fromScene)
 	assert.Equal(t, to, trans.toScene)
-	assert.Equal(t, now, trans.initialTime)
+	assert.Equal(t, now, trans.timeline.driver.(*durationDriver).last)
 	assert.True(t, trans.isFadingIn)
 }
 
@@ -404,6 +546,128 @@ func TestTimedSlideTransition_Start(t *testing.T) {
 
 	assert.Equal(t, from, trans.fromScene)
 	assert.Equal(t, to, trans.toScene)
-	assert.Equal(t, now, trans.initialTime)
+	assert.Equal(t, now, trans.timeline.driver.(*durationDriver).last)
 	assert.Equal(t, TopToBottom, trans.direction)
 }
+
+func TestBaseTransition_Ease(t *testing.T) {
+	trans := &baseTransitionImplementation{}
+	assert.Equal(t, .5, trans.Ease(.5))
+
+	trans.SetOptions(WithEasing(EaseInQuad))
+	assert.Equal(t, .25, trans.Ease(.5))
+}
+
+func TestTransition_WithEasing(t *testing.T) {
+	from := &MockScene{}
+	to := &MockScene{}
+	transitions := []interface {
+		SceneTransition[int]
+		Ease(float64) float64
+	}{
+		NewFadeTransition[int](.5, WithEasing(EaseInQuad)),
+		NewSlideTransition[int](LeftToRight, .5, WithEasing(EaseInQuad)),
+		NewTicksTimedFadeTransition[int](time.Second, WithEasing(EaseInQuad)),
+		NewTicksTimedSlideTransition[int](LeftToRight, time.Second, WithEasing(EaseInQuad)),
+		NewDurationTimedFadeTransition[int](time.Second, WithEasing(EaseInQuad)),
+		NewDurationTimedSlideTransition[int](LeftToRight, time.Second, WithEasing(EaseInQuad)),
+	}
+
+	for _, trans := range transitions {
+		assert.Equal(t, .25, trans.Ease(.5))
+
+		trans.Start(from, to, nil)
+		trans.Update()
+		trans.Draw(ebiten.NewImage(100, 100))
+	}
+}
+
+func TestBaseTransition_PreDraw(t *testing.T) {
+	from := &MockScene{}
+	to := &MockScene{}
+	sm := NewSceneManager[int](from, 0)
+	trans := &baseTransitionImplementation{}
+	sm.SwitchWithTransition(to, trans)
+
+	bounds := image.Rect(0, 0, 100, 100)
+	toImg, fromImg := trans.PreDraw(bounds)
+	assert.True(t, from.drawCalled)
+	assert.True(t, to.drawCalled)
+	assert.NotSame(t, toImg, fromImg)
+
+	// The buffers are reused while the size doesn't change
+	toImg2, fromImg2 := trans.PreDraw(bounds)
+	assert.Same(t, toImg, toImg2)
+	assert.Same(t, fromImg, fromImg2)
+
+	toImg3, _ := trans.PreDraw(image.Rect(0, 0, 50, 50))
+	assert.NotSame(t, toImg, toImg3)
+	assert.Equal(t, 50, toImg3.Bounds().Dx())
+
+	// The buffers are released when the transition ends
+	trans.End()
+	assert.Nil(t, trans.fromImg.img)
+	assert.Nil(t, trans.toImg.img)
+	assert.Equal(t, to, sm.current)
+}
+
+func TestTransitions_DrawReusesBuffers(t *testing.T) {
+	fade := NewFadeTransition[int](.01)
+	crossfade := NewCrossfadeTransition[int](NewFactorTimeline(.01))
+	slide := NewSlideTransition[int](LeftToRight, .01)
+	screen := ebiten.NewImage(320, 240)
+	for name, tc := range map[string]struct {
+		trans SceneTransition[int]
+		base  *BaseTransition[int]
+	}{
+		"fade":      {fade, &fade.BaseTransition},
+		"crossfade": {crossfade, &crossfade.BaseTransition},
+		"slide":     {slide, &slide.BaseTransition},
+	} {
+		trans, base := tc.trans, tc.base
+		trans.Start(&MockScene{}, &MockScene{}, nil)
+		trans.Update()
+		trans.Draw(screen)
+		fromImg, toImg := base.fromImg.img, base.toImg.img
+		assert.NotNil(t, fromImg, name)
+		assert.NotNil(t, toImg, name)
+
+		// No frame allocates a new buffer while the size doesn't change
+		for i := 0; i < 50; i++ {
+			trans.Update()
+			trans.Draw(screen)
+		}
+		assert.Same(t, fromImg, base.fromImg.img, name)
+		assert.Same(t, toImg, base.toImg.img, name)
+	}
+}
+
+// The draws reuse the buffers of the transition, which TestTransitions_DrawReusesBuffers checks. The
+// allocations reported don't come from stagehand but from Ebitengine, which queues the draw commands
+// while the game loop isn't running, so they only compare benchmarks run the same way, see
+// BenchmarkPreDraw for the cost without the buffers
+func BenchmarkFadeTransition_Draw(b *testing.B) {
+	trans := NewFadeTransition[int](.01)
+	trans.Start(&MockScene{}, &MockScene{}, nil)
+	trans.Update()
+	screen := ebiten.NewImage(320, 240)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		trans.Draw(screen)
+	}
+}
+
+func BenchmarkSlideTransition_Draw(b *testing.B) {
+	trans := NewSlideTransition[int](LeftToRight, .01)
+	trans.Start(&MockScene{}, &MockScene{}, nil)
+	trans.Update()
+	screen := ebiten.NewImage(320, 240)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		trans.Draw(screen)
+	}
+}
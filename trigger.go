@@ -0,0 +1,48 @@
+package stagehand
+
+import (
+	"fmt"
+
+	"github.com/joelschutz/stagehand/ruleset"
+)
+
+var (
+	ErrUnknownTrigger   = ruleset.ErrUnknownTrigger
+	ErrDuplicateTrigger = ruleset.ErrDuplicateTrigger
+)
+
+type SceneTransitionTrigger = ruleset.Trigger
+
+// Triggers is the registry used to name triggers in logs, rule sets and ProcessTriggerByName
+var Triggers = ruleset.Triggers
+
+// A TriggerRegistry maps names to triggers and back, so triggers can be referenced by name while
+// directives still match them as integers. It's safe for concurrent use
+type TriggerRegistry = ruleset.TriggerRegistry
+
+func NewTriggerRegistry() *TriggerRegistry {
+	return ruleset.NewTriggerRegistry()
+}
+
+// SetTriggerRegistry sets the registry used by ProcessTriggerByName and to name the triggers in the
+// diagrams, Triggers by default
+func (d *SceneDirector[T]) SetTriggerRegistry(triggers *TriggerRegistry) {
+	d.triggers = triggers
+}
+
+func (d *SceneDirector[T]) TriggerRegistry() *TriggerRegistry {
+	if d.triggers == nil {
+		return Triggers
+	}
+	return d.triggers
+}
+
+// ProcessTriggerByName processes the trigger registered with the name in the trigger registry of the
+// director
+func (d *SceneDirector[T]) ProcessTriggerByName(name string) (*Directive[T], error) {
+	trigger, ok := d.TriggerRegistry().Lookup(name)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownTrigger, name)
+	}
+	return d.ProcessTrigger(trigger)
+}
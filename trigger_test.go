@@ -0,0 +1,26 @@
+package stagehand
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSceneDirector_ProcessTriggerByName(t *testing.T) {
+	sceneA, sceneB := &MockScene{}, &MockScene{}
+	triggers := NewTriggerRegistry()
+	trigger := triggers.Define("byName")
+	director := NewSceneDirector[int](sceneA, 0, map[Scene[int]][]Directive[int]{
+		sceneA: {{Dest: sceneB, Trigger: trigger}},
+	})
+	assert.Same(t, Triggers, director.TriggerRegistry())
+	director.SetTriggerRegistry(triggers)
+
+	_, err := director.ProcessTriggerByName("missing")
+	assert.ErrorIs(t, err, ErrUnknownTrigger)
+
+	fired, err := director.ProcessTriggerByName("byName")
+	assert.NoError(t, err)
+	assert.NotNil(t, fired)
+	assert.Same(t, sceneB, director.current)
+}
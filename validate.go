@@ -0,0 +1,182 @@
+package stagehand
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/joelschutz/stagehand/ruleset"
+)
+
+// An IssueKind classifies the issues found by Validate
+type IssueKind = ruleset.IssueKind
+
+const (
+	UnreachableScene   = ruleset.UnreachableScene
+	DeadEndScene       = ruleset.DeadEndScene
+	TriggerConflict    = ruleset.TriggerConflict
+	MissingDestination = ruleset.MissingDestination
+	SharedTransition   = ruleset.SharedTransition
+)
+
+// An Issue is a problem found in the rules of a SceneDirector
+type Issue = ruleset.Issue
+
+// key returns the reference used to identify the scene in the graph
+func (d *SceneDirector[T]) key(scene Scene[T]) sceneRef[T] {
+	ref := d.ref(scene)
+	if ref.id != "" {
+		ref.scene = nil
+	}
+	return ref
+}
+
+// graph collects every scene known by the director and the directives between them. Scenes are
+// labeled by their ID, or by their type, numbered if it repeats, if they have none. The scenes are
+// numbered in the order they are reached from the start scene, the ones it doesn't reach follow
+// sorted by ID or type, so scenes of a repeated type should have an ID if they are unreachable
+func (d *SceneDirector[T]) graph() *ruleset.Graph {
+	var keys []sceneRef[T]
+	seen := make(map[sceneRef[T]]bool)
+	var queue []sceneRef[T]
+	add := func(key sceneRef[T]) {
+		if !seen[key] {
+			seen[key] = true
+			queue = append(queue, key)
+		}
+	}
+
+	rules := make(map[sceneRef[T]][]*Directive[T])
+	visit := func() {
+		for len(queue) > 0 {
+			key := queue[0]
+			queue = queue[1:]
+			keys = append(keys, key)
+
+			ref := key
+			if ref.id != "" && d.registry != nil {
+				ref.scene = d.registry.instances[ref.id]
+			}
+			rules[key] = d.refDirectives(ref)
+			for _, directive := range rules[key] {
+				if dest, ok := d.destKey(*directive); ok {
+					add(dest)
+				}
+			}
+		}
+	}
+	add(d.key(d.start))
+	visit()
+
+	var roots []sceneRef[T]
+	for scene := range d.RuleSet {
+		roots = append(roots, d.key(scene))
+	}
+	for scene := range d.Groups {
+		roots = append(roots, d.key(scene))
+	}
+	for id := range d.IDRuleSet {
+		roots = append(roots, sceneRef[T]{id: id})
+	}
+	for id := range d.IDGroups {
+		roots = append(roots, sceneRef[T]{id: id})
+	}
+	if d.registry != nil {
+		for _, id := range d.registry.IDs() {
+			roots = append(roots, sceneRef[T]{id: id})
+		}
+	}
+	sort.SliceStable(roots, func(i, j int) bool { return roots[i].order() < roots[j].order() })
+	for _, root := range roots {
+		add(root)
+		visit()
+	}
+
+	g := &ruleset.Graph{Rules: make(map[string][]ruleset.Rule, len(keys)), Triggers: d.TriggerRegistry()}
+	labels := make(map[sceneRef[T]]string, len(keys))
+	numbered := make(map[string]int)
+	for _, key := range keys {
+		label := string(key.id)
+		if label == "" {
+			// Scenes without ID are named after their type
+			label = typeName(key.scene)
+			if numbered[label]++; numbered[label] > 1 {
+				label += " #" + strconv.Itoa(numbered[label])
+			}
+		}
+		labels[key] = label
+		g.Scenes = append(g.Scenes, label)
+	}
+	sort.Strings(g.Scenes)
+	g.Start = labels[d.key(d.start)]
+	if scene, ok := d.current.(Scene[T]); ok {
+		g.Current = labels[d.key(unwrapScene(scene))]
+	}
+
+	directives := make(map[*Directive[T]]int)
+	// Transitions are identified by address, the ones that aren't pointers are copied into every
+	// directive and may not be comparable
+	instances := make(map[uintptr]int)
+	count := 0
+	instance := func(t SceneTransition[T]) int {
+		v := reflect.ValueOf(t)
+		if v.Kind() != reflect.Pointer {
+			count++
+			return count
+		}
+		if instances[v.Pointer()] == 0 {
+			count++
+			instances[v.Pointer()] = count
+		}
+		return instances[v.Pointer()]
+	}
+	for _, key := range keys {
+		for _, directive := range rules[key] {
+			if directives[directive] == 0 {
+				directives[directive] = len(directives) + 1
+			}
+			rule := ruleset.Rule{
+				Trigger:   directive.Trigger,
+				Guarded:   directive.Guard != nil,
+				Directive: directives[directive],
+			}
+			if dest, ok := d.destKey(*directive); ok {
+				rule.Dest = labels[dest]
+			} else {
+				rule.DestID = string(directive.DestID)
+			}
+			if directive.Transition != nil {
+				rule.Transition = typeName(directive.Transition)
+				rule.Instance = instance(directive.Transition)
+			}
+			g.Rules[labels[key]] = append(g.Rules[labels[key]], rule)
+		}
+	}
+	return g
+}
+
+// destKey returns the key of the destination of the directive, if it has a valid one
+func (d *SceneDirector[T]) destKey(directive Directive[T]) (sceneRef[T], bool) {
+	if directive.Dest != nil {
+		return d.key(directive.Dest), true
+	}
+	if directive.DestID != "" && d.registry != nil && d.registry.Has(directive.DestID) {
+		return sceneRef[T]{id: directive.DestID}, true
+	}
+	return sceneRef[T]{}, false
+}
+
+// order sorts the scenes the start scene doesn't reach, by ID or by type if they have none
+func (r sceneRef[T]) order() string {
+	if r.id != "" {
+		return string(r.id)
+	}
+	return typeName(r.scene)
+}
+
+// Validate analyzes the flow between the scenes of the director, from the scene it started at, and
+// returns the issues found sorted by kind and scene. A scene is known to the director if it has rules
+// or groups, is registered in its registry or is the destination of a directive
+func (d *SceneDirector[T]) Validate() []Issue {
+	return d.graph().Validate()
+}
@@ -0,0 +1,103 @@
+package stagehand
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func issueKinds(issues []Issue) map[IssueKind][]string {
+	kinds := make(map[IssueKind][]string)
+	for _, issue := range issues {
+		kinds[issue.Kind] = append(kinds[issue.Kind], issue.Scene)
+	}
+	return kinds
+}
+
+func TestSceneDirector_Validate(t *testing.T) {
+	r := NewRegistry[int]()
+	for _, id := range []SceneID{"menu", "level", "credits", "secret"} {
+		r.Register(id, func() Scene[int] { return &MockScene{} })
+	}
+	shared := &baseTransitionImplementation{}
+	director, err := NewSceneDirectorWithRegistry[int](r, "menu", 0, map[SceneID][]Directive[int]{
+		"menu": {
+			{DestID: "level", Trigger: 1, Transition: shared},
+			{DestID: "credits", Trigger: 1},
+			{DestID: "missing", Trigger: 2},
+			{Trigger: 3},
+		},
+		"level":  {{DestID: "menu", Trigger: 4, Transition: shared}},
+		"secret": {{DestID: "menu", Trigger: 4}},
+	})
+	assert.NoError(t, err)
+
+	kinds := issueKinds(director.Validate())
+	assert.Equal(t, []string{"credits", "secret"}, kinds[UnreachableScene])
+	assert.Equal(t, []string{"credits"}, kinds[DeadEndScene])
+	assert.Equal(t, []string{"menu"}, kinds[TriggerConflict])
+	assert.Equal(t, []string{"menu", "menu"}, kinds[MissingDestination])
+	assert.Equal(t, []string{"level"}, kinds[SharedTransition])
+}
+
+func TestSceneDirector_GraphLabels(t *testing.T) {
+	for i := 0; i < 10; i++ {
+		// The scenes are allocated in reverse, their labels follow the flow from the start scene
+		credits := &MockScene{}
+		level := &MockScene{}
+		menu := &MockScene{}
+		director := NewSceneDirector[int](menu, 0, map[Scene[int]][]Directive[int]{
+			menu:    {{Dest: level, Trigger: 1}},
+			level:   {{Dest: credits, Trigger: 1}},
+			credits: {{Dest: menu, Trigger: 1}},
+		})
+
+		g := director.graph()
+		assert.Equal(t, "MockScene", g.Start)
+		assert.Equal(t, "MockScene #2", g.Rules["MockScene"][0].Dest)
+		assert.Equal(t, "MockScene #3", g.Rules["MockScene #2"][0].Dest)
+		assert.Equal(t, "MockScene", g.Rules["MockScene #3"][0].Dest)
+	}
+}
+
+func TestSceneDirector_ValidateClean(t *testing.T) {
+	sceneA, sceneB := &MockScene{}, &MockScene{}
+	director := NewSceneDirector[int](sceneA, 0, map[Scene[int]][]Directive[int]{
+		sceneA: {
+			{Dest: sceneB, Trigger: 1, Guard: func(int) bool { return true }},
+			{Dest: sceneB, Trigger: 1, Transition: &baseTransitionImplementation{}},
+		},
+	})
+	director.GlobalRuleSet = []Directive[int]{{Dest: sceneA, Trigger: 2, Transition: &baseTransitionImplementation{}}}
+
+	// Guarded directives don't conflict and global directives leave every scene
+	assert.Empty(t, director.Validate())
+}
+
+// frameTransition can't be used as a map key, its frames are a slice
+type frameTransition struct {
+	*baseTransitionImplementation
+	frames []int
+}
+
+func TestSceneDirector_ValidateUncomparableTransition(t *testing.T) {
+	sceneA, sceneB := &MockScene{}, &MockScene{}
+	shared := &frameTransition{baseTransitionImplementation: &baseTransitionImplementation{}}
+	copied := frameTransition{baseTransitionImplementation: &baseTransitionImplementation{}, frames: []int{1}}
+	director := NewSceneDirector[int](sceneA, 0, map[Scene[int]][]Directive[int]{
+		sceneA: {{Dest: sceneB, Trigger: 1, Transition: copied}, {Dest: sceneB, Trigger: 2, Transition: shared}},
+		sceneB: {{Dest: sceneA, Trigger: 1, Transition: copied}, {Dest: sceneA, Trigger: 2, Transition: shared}},
+	})
+
+	// Transitions that aren't pointers are copied into every directive, so they're never shared
+	var issues []Issue
+	assert.NotPanics(t, func() { issues = director.Validate() })
+	assert.Len(t, issues, 1)
+	assert.Equal(t, SharedTransition, issues[0].Kind)
+	assert.Contains(t, issues[0].Message, "frameTransition")
+}
+
+func TestIssue_String(t *testing.T) {
+	issue := Issue{Kind: DeadEndScene, Scene: "credits", Message: "no directive leaves this scene"}
+	assert.Equal(t, "credits: dead end: no directive leaves this scene", issue.String())
+}